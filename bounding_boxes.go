@@ -0,0 +1,170 @@
+package rlfp
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Box with collisions
+type CollisionBox struct {
+	// Stable identifier of the box
+	ID BoxID
+	// The box that the player collides with
+	BoundingBox rl.BoundingBox
+	// Rotated shape of the box, used instead of BoundingBox when IsOriented is true, BoundingBox is then the axis-aligned box around it
+	Oriented OrientedBox
+	// If the box has a rotated shape
+	IsOriented bool
+	// If the box collides even when it's farther than world.CalculationDistance
+	AlwaysActive bool
+	// If the box doesn't collide at all
+	Disabled bool
+	// If the player can interact with interactable boxes through this box, e.g. glass or grates
+	SeeThrough bool
+	// Velocity of a kinematic or dynamic box in units per second, kinematic boxes move by themselves and carry or push the player
+	Velocity rl.Vector3
+	// Path of a kinematic box, used instead of Velocity when it has waypoints
+	Path KinematicPath
+	// If the box is a rigid box which falls with world.Gravity and is stopped by other boxes, added with world.AddRigidBox
+	Dynamic bool
+	// Mass of a dynamic box, heavier boxes keep more of their speed when they hit lighter ones
+	Mass float32
+	// How fast a dynamic box slows down when it lies on something in units per second squared
+	Friction float32
+	// If the player can push a dynamic box by walking into it
+	Pushable bool
+	// If a dynamic box lies still and isn't updated until something moves it
+	Resting bool
+}
+
+// Adds a new bounding box to the world
+//
+// #1 argument box: rl.BoundingBox - bounding box to add
+//
+// #1 return: BoxID - stable ID of the new box
+func (world *World) AddBoundingBox(box rl.BoundingBox) BoxID {
+	id := world.newBoxID()
+
+	world.BoundingBoxes = append(world.BoundingBoxes, CollisionBox{
+		ID:          id,
+		BoundingBox: box,
+	})
+	if world.bounding_box_grid.isInitialized() {
+		world.bounding_box_grid.insert(len(world.BoundingBoxes)-1, box)
+	}
+	if world.bounding_box_indexes != nil {
+		world.bounding_box_indexes[id] = len(world.BoundingBoxes) - 1
+	}
+
+	return id
+}
+
+// Removes a bounding box from the world, the last box takes its place in world.BoundingBoxes
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #1 return: bool - false if there is no bounding box with the ID
+func (world *World) RemoveBoundingBox(id BoxID) bool {
+	i := world.getBoxIndex(&world.bounding_box_indexes, len(world.BoundingBoxes), id)
+	if i == -1 {
+		return false
+	}
+	last := len(world.BoundingBoxes) - 1
+
+	world.bounding_box_grid.remove(i)
+	if i != last {
+		world.bounding_box_grid.remove(last)
+		world.bounding_box_grid.insert(i, world.BoundingBoxes[last].BoundingBox)
+		world.BoundingBoxes[i] = world.BoundingBoxes[last]
+		world.bounding_box_indexes[world.BoundingBoxes[i].ID] = i
+	}
+	world.BoundingBoxes = world.BoundingBoxes[:last]
+	delete(world.bounding_box_indexes, id)
+	world.kinematic_boxes = fixKeysAfterRemove(world.kinematic_boxes, i, last)
+	world.dynamic_boxes = fixKeysAfterRemove(world.dynamic_boxes, i, last)
+
+	return true
+}
+
+// Moves or resizes a bounding box, an oriented box becomes axis-aligned
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #2 argument box: rl.BoundingBox - the new box
+//
+// #1 return: bool - false if there is no bounding box with the ID
+func (world *World) SetBoundingBoxBounds(id BoxID, box rl.BoundingBox) bool {
+	i := world.getBoxIndex(&world.bounding_box_indexes, len(world.BoundingBoxes), id)
+	if i == -1 {
+		return false
+	}
+
+	world.bounding_box_grid.remove(i)
+	world.BoundingBoxes[i].BoundingBox = box
+	world.BoundingBoxes[i].IsOriented = false
+	world.BoundingBoxes[i].Resting = false
+	world.bounding_box_grid.insert(i, box)
+
+	return true
+}
+
+// Gets a bounding box by its ID
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #1 return: CollisionBox - copy of the box
+//
+// #2 return: bool - false if there is no bounding box with the ID
+func (world *World) GetBoundingBox(id BoxID) (CollisionBox, bool) {
+	i := world.getBoxIndex(&world.bounding_box_indexes, len(world.BoundingBoxes), id)
+	if i == -1 {
+		return CollisionBox{}, false
+	}
+
+	return world.BoundingBoxes[i], true
+}
+
+// Enables or disables collisions of a bounding box
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #2 argument enabled: bool - if the box should collide
+//
+// #1 return: bool - false if there is no bounding box with the ID
+func (world *World) SetBoundingBoxEnabled(id BoxID, enabled bool) bool {
+	i := world.getBoxIndex(&world.bounding_box_indexes, len(world.BoundingBoxes), id)
+	if i == -1 {
+		return false
+	}
+
+	world.BoundingBoxes[i].Disabled = !enabled
+
+	return true
+}
+
+// Sets if the player can interact with interactable boxes through a bounding box
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #2 argument see_through: bool - if the box doesn't block interaction
+//
+// #1 return: bool - false if there is no bounding box with the ID
+func (world *World) SetBoundingBoxSeeThrough(id BoxID, see_through bool) bool {
+	i := world.getBoxIndex(&world.bounding_box_indexes, len(world.BoundingBoxes), id)
+	if i == -1 {
+		return false
+	}
+
+	world.BoundingBoxes[i].SeeThrough = see_through
+
+	return true
+}
+
+// Checks if a bounding box can collide with the player this frame
+//
+// #1 argument i: int - index of the bounding box
+//
+// #1 return: bool - true if the box is enabled and close enough to the player
+func (world *World) isBoundingBoxActive(i int) bool {
+	return !world.BoundingBoxes[i].Disabled &&
+		world.isInCalculationDistance(world.BoundingBoxes[i].BoundingBox, world.BoundingBoxes[i].AlwaysActive)
+}
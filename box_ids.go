@@ -0,0 +1,89 @@
+package rlfp
+
+// Stable identifier of a box in the world, it doesn't change when other boxes are added or removed (0 is never used)
+type BoxID uint32
+
+// Gives a new unique ID to a box
+//
+// #1 return: BoxID - the new ID
+func (world *World) newBoxID() BoxID {
+	world.NextBoxID++
+
+	return world.NextBoxID
+}
+
+// Rebuilds the lookups from IDs to indexes of every kind of box, boxes without an ID get a new one
+func (world *World) rebuildBoxIndexes() {
+	world.bounding_box_indexes = make(map[BoxID]int, len(world.BoundingBoxes))
+	for i := range world.BoundingBoxes {
+		if world.BoundingBoxes[i].ID == 0 {
+			world.BoundingBoxes[i].ID = world.newBoxID()
+		}
+		world.bounding_box_indexes[world.BoundingBoxes[i].ID] = i
+	}
+
+	world.trigger_box_indexes = make(map[BoxID]int, len(world.TriggerBoxes))
+	for i := range world.TriggerBoxes {
+		if world.TriggerBoxes[i].ID == 0 {
+			world.TriggerBoxes[i].ID = world.newBoxID()
+		}
+		world.trigger_box_indexes[world.TriggerBoxes[i].ID] = i
+	}
+
+	world.interactable_box_indexes = make(map[BoxID]int, len(world.InteractableBoxes))
+	for i := range world.InteractableBoxes {
+		if world.InteractableBoxes[i].ID == 0 {
+			world.InteractableBoxes[i].ID = world.newBoxID()
+		}
+		world.interactable_box_indexes[world.InteractableBoxes[i].ID] = i
+	}
+
+	world.collider_indexes = make(map[BoxID]int, len(world.Colliders))
+	for i := range world.Colliders {
+		if world.Colliders[i].ID == 0 {
+			world.Colliders[i].ID = world.newBoxID()
+		}
+		world.collider_indexes[world.Colliders[i].ID] = i
+	}
+}
+
+// Gets the index of a box from a lookup, rebuilds the lookups when the slices were changed directly
+//
+// #1 argument indexes: *map[BoxID]int - the lookup of the kind of box
+//
+// #2 argument count: int - length of the slice of the kind of box
+//
+// #3 argument id: BoxID - ID of the box
+//
+// #1 return: int - index of the box, -1 if there is no box with the ID
+func (world *World) getBoxIndex(indexes *map[BoxID]int, count int, id BoxID) int {
+	if *indexes == nil || len(*indexes) != count {
+		world.RebuildSpatialGrids()
+	}
+
+	if i, ok := (*indexes)[id]; ok {
+		return i
+	}
+
+	return -1
+}
+
+// Fixes a list of indexes after the box at index removed was replaced by the last box at index moved
+//
+// #1 argument keys: []int - the list of indexes
+//
+// #2 argument removed: int - index of the removed box
+//
+// #3 argument moved: int - old index of the box that was moved to removed
+//
+// #1 return: []int - the fixed list
+func fixKeysAfterRemove(keys []int, removed, moved int) []int {
+	keys = removeKey(keys, removed)
+	for i := range keys {
+		if keys[i] == moved {
+			keys[i] = removed
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,112 @@
+package rlfp
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Creates a box of the size 1 at an X position
+//
+// #1 argument x: float32 - X position of the box
+//
+// #1 return: rl.BoundingBox - the box
+func getTestBox(x float32) rl.BoundingBox {
+	return rl.BoundingBox{Min: rl.Vector3{X: x, Y: 0., Z: 0.}, Max: rl.Vector3{X: x + 1., Y: 1., Z: 1.}}
+}
+
+// Box IDs stay valid after other boxes are removed and removed IDs aren't found
+func TestBoxIDs(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	first := world.AddBoundingBox(getTestBox(5.))
+	second := world.AddBoundingBox(getTestBox(10.))
+	third := world.AddBoundingBox(getTestBox(15.))
+	if first == second || second == third || first == 0 {
+		t.Fatalf("boxes got IDs %d, %d and %d, want unique non zero IDs", first, second, third)
+	}
+
+	if !world.RemoveBoundingBox(first) || world.RemoveBoundingBox(first) {
+		t.Error("box wasn't removed exactly once")
+	}
+	if _, ok := world.GetBoundingBox(first); ok {
+		t.Error("removed box was found")
+	}
+	if box, ok := world.GetBoundingBox(third); !ok || box.BoundingBox != getTestBox(15.) {
+		t.Errorf("got %v for the last box after removing the first one, want %v", box.BoundingBox, getTestBox(15.))
+	}
+
+	if !world.SetBoundingBoxBounds(second, getTestBox(20.)) {
+		t.Fatal("box wasn't moved")
+	}
+	if box, _ := world.GetBoundingBox(second); box.BoundingBox != getTestBox(20.) {
+		t.Errorf("moved box is at %v, want %v", box.BoundingBox, getTestBox(20.))
+	}
+	if world.SetBoundingBoxBounds(first, getTestBox(0.)) {
+		t.Error("removed box was moved")
+	}
+}
+
+// Boxes appended to the world's slices directly get an ID in the next frame
+func TestBoxIDsOfAppendedBoxes(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	world.AddBoundingBox(getTestBox(5.))
+	world.BoundingBoxes = append(world.BoundingBoxes, CollisionBox{BoundingBox: getTestBox(10.)})
+	world.TriggerBoxes = append(world.TriggerBoxes, TriggerBox{BoundingBox: getTestBox(15.)})
+
+	stepTestWorld(world, 1, [ControlCount]bool{})
+	if world.BoundingBoxes[1].ID == 0 || world.BoundingBoxes[1].ID == world.BoundingBoxes[0].ID || world.TriggerBoxes[0].ID == 0 {
+		t.Errorf("appended boxes got IDs %d and %d", world.BoundingBoxes[1].ID, world.TriggerBoxes[0].ID)
+	}
+	if box, ok := world.GetBoundingBox(world.BoundingBoxes[1].ID); !ok || box.BoundingBox != getTestBox(10.) {
+		t.Error("appended box isn't found by its ID")
+	}
+}
+
+// Disabled and removed trigger and interactable boxes aren't updated
+func TestRemoveAndDisableBoxes(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	area := rl.BoundingBox{Min: rl.Vector3{X: -1., Y: 0., Z: -1.}, Max: rl.Vector3{X: 1., Y: 3., Z: 1.}}
+	trigger := world.AddTriggerBox(area)
+	world.AddInteractableBox(area)
+
+	world.SetTriggerBoxEnabled(trigger, false)
+	stepTestWorld(world, 1, [ControlCount]bool{})
+	if box, _ := world.GetTriggerBox(trigger); box.Triggering {
+		t.Error("disabled trigger box is triggering")
+	}
+
+	world.SetTriggerBoxEnabled(trigger, true)
+	stepTestWorld(world, 1, [ControlCount]bool{})
+	if box, _ := world.GetTriggerBox(trigger); !box.Triggering {
+		t.Error("enabled trigger box isn't triggering")
+	}
+
+	if !world.RemoveTriggerBox(trigger) || !world.RemoveInteractableBox(world.InteractableBoxes[0].ID) {
+		t.Fatal("boxes weren't removed")
+	}
+	stepTestWorld(world, 1, [ControlCount]bool{})
+	if len(world.TriggerBoxes) != 0 || len(world.InteractableBoxes) != 0 {
+		t.Errorf("world has %d trigger and %d interactable boxes after removing them", len(world.TriggerBoxes), len(world.InteractableBoxes))
+	}
+}
+
+// The player can't uncrouch under the first box of the world
+func TestCanPlayerUncrouchUnderFirstBox(t *testing.T) {
+	world := &World{}
+	world.Init(0.)
+	world.New(rl.Vector3{X: 0., Y: 1., Z: 0.}, rl.Vector2{X: 0., Y: 0.}, true)
+	stepTestWorld(world, 30, getTestInputs(ControlCrouch))
+	if !world.Player.IsCrouching {
+		t.Fatal("player isn't crouching")
+	}
+
+	ceiling := world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: -1., Y: world.Player.BoundingBox.Max.Y + .1, Z: -1.}, Max: rl.Vector3{X: 1., Y: 3., Z: 1.}})
+	if world.CanPlayerUncrouch() {
+		t.Error("player can uncrouch under the box at index 0")
+	}
+
+	world.RemoveBoundingBox(ceiling)
+	if !world.CanPlayerUncrouch() {
+		t.Error("player can't uncrouch after the box was removed")
+	}
+}
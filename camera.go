@@ -56,3 +56,13 @@ func (player *Player) UpdateCameraFOVY() {
 		player.Camera.Fovy = player.Fovs.Normal
 	}
 }
+
+// Gets the ray going from the camera through the center of the screen
+//
+// #1 return: rl.Ray - the ray used for interacting
+func (player *Player) GetLookRay() rl.Ray {
+	return rl.Ray{
+		Position:  player.Camera.Position,
+		Direction: rl.Vector3Normalize(rl.Vector3Subtract(player.Camera.Target, player.Camera.Position)),
+	}
+}
@@ -0,0 +1,526 @@
+package rlfp
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"github.com/chewxy/math32"
+)
+
+// How many times a sweep is halved when looking for the time of impact with a sphere or a capsule
+const sweepBisections = 16
+
+// Shape that the player and dynamic boxes collide with
+// Implemented by BoxCollider, SphereCollider, CapsuleCollider, *MeshCollider and OrientedBox
+type Collider interface {
+	// Gets the smallest axis-aligned box around the shape, used for finding nearby colliders
+	GetBoundingBox() rl.BoundingBox
+	// Checks if an axis-aligned box collides with the shape, touching counts as a collision
+	CheckCollisionBox(box rl.BoundingBox) bool
+	// Finds when a moving axis-aligned box hits the shape, returns the part of the offset moved before the hit,
+	// the normal of the hit surface and false if the box doesn't hit the shape or is already inside it
+	SweepBox(box rl.BoundingBox, offset rl.Vector3) (float32, rl.Vector3, bool)
+	// Finds where a ray hits the shape
+	GetRayCollision(ray rl.Ray) rl.RayCollision
+}
+
+// Axis-aligned box collider
+type BoxCollider struct {
+	Box rl.BoundingBox
+}
+
+// Sphere collider
+type SphereCollider struct {
+	Center rl.Vector3
+	Radius float32
+}
+
+// Capsule collider, a segment with a radius around it
+type CapsuleCollider struct {
+	// Centers of the two half spheres at the ends
+	Start rl.Vector3
+	End   rl.Vector3
+	// Radius of the capsule
+	Radius float32
+}
+
+// Static shape in the world
+type WorldCollider struct {
+	// Stable identifier of the collider
+	ID BoxID
+	// The shape
+	Collider Collider
+	// Axis-aligned box around the shape, used for finding nearby colliders
+	BoundingBox rl.BoundingBox
+	// If the collider collides even when it's farther than world.CalculationDistance
+	AlwaysActive bool
+	// If the collider doesn't collide at all
+	Disabled bool
+	// If the player can interact with interactable boxes through this collider
+	SeeThrough bool
+}
+
+// Gets the box
+//
+// #1 return: rl.BoundingBox - the box
+func (collider BoxCollider) GetBoundingBox() rl.BoundingBox {
+	return collider.Box
+}
+
+// Checks if an axis-aligned box collides with the box
+//
+// #1 argument box: rl.BoundingBox - the axis-aligned box
+//
+// #1 return: bool - true if the boxes collide
+func (collider BoxCollider) CheckCollisionBox(box rl.BoundingBox) bool {
+	return rl.CheckCollisionBoxes(box, collider.Box)
+}
+
+// Finds when a moving axis-aligned box hits the box
+//
+// #1 argument box: rl.BoundingBox - the moving box
+//
+// #2 argument offset: rl.Vector3 - how far the box moves
+//
+// #1 return: float32 - part of the offset the box moves before the hit, from 0 to 1
+//
+// #2 return: rl.Vector3 - normal of the hit side
+//
+// #3 return: bool - false if the box doesn't hit the collider or is already inside it
+func (collider BoxCollider) SweepBox(box rl.BoundingBox, offset rl.Vector3) (float32, rl.Vector3, bool) {
+	return SweepBoundingBox(box, offset, collider.Box)
+}
+
+// Finds where a ray hits the box
+//
+// #1 argument ray: rl.Ray - the ray
+//
+// #1 return: rl.RayCollision - the collision
+func (collider BoxCollider) GetRayCollision(ray rl.Ray) rl.RayCollision {
+	return rl.GetRayCollisionBox(ray, collider.Box)
+}
+
+// Gets the smallest axis-aligned box around the sphere
+//
+// #1 return: rl.BoundingBox - the box
+func (collider SphereCollider) GetBoundingBox() rl.BoundingBox {
+	return rl.BoundingBox{
+		Min: rl.Vector3SubtractValue(collider.Center, collider.Radius),
+		Max: rl.Vector3AddValue(collider.Center, collider.Radius),
+	}
+}
+
+// Checks if an axis-aligned box collides with the sphere
+//
+// #1 argument box: rl.BoundingBox - the axis-aligned box
+//
+// #1 return: bool - true if the box collides with the sphere
+func (collider SphereCollider) CheckCollisionBox(box rl.BoundingBox) bool {
+	return rl.CheckCollisionBoxSphere(box, collider.Center, collider.Radius)
+}
+
+// Finds when a moving axis-aligned box hits the sphere
+//
+// #1 argument box: rl.BoundingBox - the moving box
+//
+// #2 argument offset: rl.Vector3 - how far the box moves
+//
+// #1 return: float32 - part of the offset the box moves before the hit, from 0 to 1
+//
+// #2 return: rl.Vector3 - normal of the sphere at the hit
+//
+// #3 return: bool - false if the box doesn't hit the sphere or is already inside it
+func (collider SphereCollider) SweepBox(box rl.BoundingBox, offset rl.Vector3) (float32, rl.Vector3, bool) {
+	time, ok := sweepBoxBySteps(box, offset, collider.Radius, collider.CheckCollisionBox)
+	if !ok {
+		return 1., rl.Vector3{X: 0., Y: 0., Z: 0.}, false
+	}
+
+	// The normal goes from the center to the closest point of the box
+	moved := moveBox(box, rl.Vector3Scale(offset, time))
+	closest := rl.Vector3Clamp(collider.Center, moved.Min, moved.Max)
+
+	return time, getContactNormal(collider.Center, closest, offset), true
+}
+
+// Finds where a ray hits the sphere
+//
+// #1 argument ray: rl.Ray - the ray
+//
+// #1 return: rl.RayCollision - the collision
+func (collider SphereCollider) GetRayCollision(ray rl.Ray) rl.RayCollision {
+	return rl.GetRayCollisionSphere(ray, collider.Center, collider.Radius)
+}
+
+// Gets the smallest axis-aligned box around the capsule
+//
+// #1 return: rl.BoundingBox - the box
+func (collider CapsuleCollider) GetBoundingBox() rl.BoundingBox {
+	return rl.BoundingBox{
+		Min: rl.Vector3SubtractValue(rl.Vector3Min(collider.Start, collider.End), collider.Radius),
+		Max: rl.Vector3AddValue(rl.Vector3Max(collider.Start, collider.End), collider.Radius),
+	}
+}
+
+// Checks if an axis-aligned box collides with the capsule
+//
+// #1 argument box: rl.BoundingBox - the axis-aligned box
+//
+// #1 return: bool - true if the box collides with the capsule
+func (collider CapsuleCollider) CheckCollisionBox(box rl.BoundingBox) bool {
+	segment_point, box_point := collider.getClosestPoints(box)
+
+	return rl.Vector3DistanceSqr(segment_point, box_point) <= collider.Radius*collider.Radius
+}
+
+// Finds when a moving axis-aligned box hits the capsule
+//
+// #1 argument box: rl.BoundingBox - the moving box
+//
+// #2 argument offset: rl.Vector3 - how far the box moves
+//
+// #1 return: float32 - part of the offset the box moves before the hit, from 0 to 1
+//
+// #2 return: rl.Vector3 - normal of the capsule at the hit
+//
+// #3 return: bool - false if the box doesn't hit the capsule or is already inside it
+func (collider CapsuleCollider) SweepBox(box rl.BoundingBox, offset rl.Vector3) (float32, rl.Vector3, bool) {
+	time, ok := sweepBoxBySteps(box, offset, collider.Radius, collider.CheckCollisionBox)
+	if !ok {
+		return 1., rl.Vector3{X: 0., Y: 0., Z: 0.}, false
+	}
+
+	segment_point, box_point := collider.getClosestPoints(moveBox(box, rl.Vector3Scale(offset, time)))
+
+	return time, getContactNormal(segment_point, box_point, offset), true
+}
+
+// Finds where a ray hits the capsule
+//
+// #1 argument ray: rl.Ray - the ray
+//
+// #1 return: rl.RayCollision - the closest collision in front of the ray
+func (collider CapsuleCollider) GetRayCollision(ray rl.Ray) rl.RayCollision {
+	closest := rl.RayCollision{Hit: false, Distance: 0., Point: rl.Vector3{X: 0., Y: 0., Z: 0.}, Normal: rl.Vector3{X: 0., Y: 0., Z: 0.}}
+	keep := func(collision rl.RayCollision) {
+		if collision.Hit && collision.Distance >= 0. && (!closest.Hit || collision.Distance < closest.Distance) {
+			closest = collision
+		}
+	}
+
+	// The half spheres at the ends
+	keep(rl.GetRayCollisionSphere(ray, collider.Start, collider.Radius))
+	keep(rl.GetRayCollisionSphere(ray, collider.End, collider.Radius))
+
+	// The cylinder between them, without the parts of the ray going along the axis
+	axis := rl.Vector3Subtract(collider.End, collider.Start)
+	length := rl.Vector3Length(axis)
+	if length == 0. {
+		return closest
+	}
+	axis = rl.Vector3Scale(axis, 1./length)
+
+	start := rl.Vector3Subtract(ray.Position, collider.Start)
+	direction := rl.Vector3Subtract(ray.Direction, rl.Vector3Scale(axis, rl.Vector3DotProduct(ray.Direction, axis)))
+	position := rl.Vector3Subtract(start, rl.Vector3Scale(axis, rl.Vector3DotProduct(start, axis)))
+
+	a := rl.Vector3DotProduct(direction, direction)
+	b := 2. * rl.Vector3DotProduct(direction, position)
+	c := rl.Vector3DotProduct(position, position) - collider.Radius*collider.Radius
+	discriminant := b*b - 4.*a*c
+	if a < 1e-8 || discriminant < 0. {
+		return closest
+	}
+
+	distance := (-b - math32.Sqrt(discriminant)) / (2. * a)
+	point := rl.Vector3Add(ray.Position, rl.Vector3Scale(ray.Direction, distance))
+	height := rl.Vector3DotProduct(rl.Vector3Subtract(point, collider.Start), axis)
+	if height >= 0. && height <= length {
+		keep(rl.RayCollision{
+			Hit:      true,
+			Distance: distance,
+			Point:    point,
+			Normal:   rl.Vector3Normalize(rl.Vector3Subtract(point, rl.Vector3Add(collider.Start, rl.Vector3Scale(axis, height)))),
+		})
+	}
+
+	return closest
+}
+
+// Gets the closest points of the capsule's segment and a box
+//
+// #1 argument box: rl.BoundingBox - the box
+//
+// #1 return: rl.Vector3 - the closest point on the segment
+//
+// #2 return: rl.Vector3 - the closest point in the box
+func (collider CapsuleCollider) getClosestPoints(box rl.BoundingBox) (rl.Vector3, rl.Vector3) {
+	segment := rl.Vector3Subtract(collider.End, collider.Start)
+	length_squared := rl.Vector3DotProduct(segment, segment)
+
+	// Project between the segment and the box until the points stop moving, both are convex so it converges
+	segment_point := rl.Vector3Lerp(collider.Start, collider.End, .5)
+	box_point := rl.Vector3Clamp(segment_point, box.Min, box.Max)
+	for iteration := 0; iteration < 8 && length_squared > 0.; iteration++ {
+		amount := rl.Clamp(rl.Vector3DotProduct(rl.Vector3Subtract(box_point, collider.Start), segment)/length_squared, 0., 1.)
+		segment_point = rl.Vector3Add(collider.Start, rl.Vector3Scale(segment, amount))
+		box_point = rl.Vector3Clamp(segment_point, box.Min, box.Max)
+	}
+
+	return segment_point, box_point
+}
+
+// Checks if an axis-aligned box collides with the oriented box
+//
+// #1 argument box: rl.BoundingBox - the axis-aligned box
+//
+// #1 return: bool - true if the boxes collide
+func (box OrientedBox) CheckCollisionBox(other rl.BoundingBox) bool {
+	return CheckCollisionBoxOrientedBox(other, box)
+}
+
+// Finds when a moving axis-aligned box hits the oriented box
+//
+// #1 argument other: rl.BoundingBox - the moving box
+//
+// #2 argument offset: rl.Vector3 - how far the box moves
+//
+// #1 return: float32 - part of the offset the box moves before the hit, from 0 to 1
+//
+// #2 return: rl.Vector3 - normal of the hit surface
+//
+// #3 return: bool - false if the box doesn't hit the oriented box or is already inside it
+func (box OrientedBox) SweepBox(other rl.BoundingBox, offset rl.Vector3) (float32, rl.Vector3, bool) {
+	return SweepBoundingBoxOrientedBox(other, offset, box)
+}
+
+// Finds where a ray hits the oriented box
+//
+// #1 argument ray: rl.Ray - the ray
+//
+// #1 return: rl.RayCollision - the collision
+func (box OrientedBox) GetRayCollision(ray rl.Ray) rl.RayCollision {
+	return GetRayCollisionOrientedBox(ray, box)
+}
+
+// Finds when a moving box starts to collide with a shape by checking the collision in small steps and halving the step with the hit
+//
+// #1 argument box: rl.BoundingBox - the moving box
+//
+// #2 argument offset: rl.Vector3 - how far the box moves
+//
+// #3 argument size: float32 - smallest size of the shape, the steps are small enough not to skip over it
+//
+// #4 argument collides: func(rl.BoundingBox) bool - checks the collision of the shape with the box
+//
+// #1 return: float32 - part of the offset the box moves before the hit, from 0 to 1
+//
+// #2 return: bool - false if the box doesn't hit the shape or is already inside it
+func sweepBoxBySteps(box rl.BoundingBox, offset rl.Vector3, size float32, collides func(rl.BoundingBox) bool) (float32, bool) {
+	if collides(box) {
+		return 1., false
+	}
+
+	step := math32.Min(size, math32.Min(box.Max.X-box.Min.X, math32.Min(box.Max.Y-box.Min.Y, box.Max.Z-box.Min.Z))) * .5
+	steps := 1
+	if step > 0. {
+		steps = int(math32.Min(math32.Ceil(rl.Vector3Length(offset)/step), 1024.))
+	}
+
+	for i := 1; i <= steps; i++ {
+		time := float32(i) / float32(steps)
+		if !collides(moveBox(box, rl.Vector3Scale(offset, time))) {
+			continue
+		}
+
+		// The box is free at low and collides at high
+		low, high := float32(i-1)/float32(steps), time
+		for bisection := 0; bisection < sweepBisections; bisection++ {
+			middle := (low + high) * .5
+			if collides(moveBox(box, rl.Vector3Scale(offset, middle))) {
+				high = middle
+			} else {
+				low = middle
+			}
+		}
+
+		return low, true
+	}
+
+	return 1., false
+}
+
+// Gets the normal at a contact of a shape and a box
+//
+// #1 argument shape_point: rl.Vector3 - the closest point of the shape's core (center or segment)
+//
+// #2 argument box_point: rl.Vector3 - the closest point of the box
+//
+// #3 argument offset: rl.Vector3 - how far the box was moving, used when the points are the same
+//
+// #1 return: rl.Vector3 - the normal pointing from the shape to the box
+func getContactNormal(shape_point rl.Vector3, box_point rl.Vector3, offset rl.Vector3) rl.Vector3 {
+	normal := rl.Vector3Subtract(box_point, shape_point)
+	if rl.Vector3Length(normal) < 1e-6 {
+		return rl.Vector3Normalize(rl.Vector3Negate(offset))
+	}
+
+	return rl.Vector3Normalize(normal)
+}
+
+// Adds a static collider to the world, the player and dynamic boxes collide with it
+//
+// #1 argument collider: Collider - the shape
+//
+// #1 return: BoxID - stable ID of the new collider
+func (world *World) AddCollider(collider Collider) BoxID {
+	id := world.newBoxID()
+	box := collider.GetBoundingBox()
+
+	world.Colliders = append(world.Colliders, WorldCollider{
+		ID:          id,
+		Collider:    collider,
+		BoundingBox: box,
+	})
+	if world.collider_grid.isInitialized() {
+		world.collider_grid.insert(len(world.Colliders)-1, box)
+	}
+	if world.collider_indexes != nil {
+		world.collider_indexes[id] = len(world.Colliders) - 1
+	}
+
+	return id
+}
+
+// Removes a collider from the world, the last collider takes its place in world.Colliders
+//
+// #1 argument id: BoxID - ID of the collider
+//
+// #1 return: bool - false if there is no collider with the ID
+func (world *World) RemoveCollider(id BoxID) bool {
+	i := world.getBoxIndex(&world.collider_indexes, len(world.Colliders), id)
+	if i == -1 {
+		return false
+	}
+	last := len(world.Colliders) - 1
+
+	world.collider_grid.remove(i)
+	if i != last {
+		world.collider_grid.remove(last)
+		world.collider_grid.insert(i, world.Colliders[last].BoundingBox)
+		world.Colliders[i] = world.Colliders[last]
+		world.collider_indexes[world.Colliders[i].ID] = i
+	}
+	world.Colliders = world.Colliders[:last]
+	delete(world.collider_indexes, id)
+
+	return true
+}
+
+// Replaces the shape of a collider
+//
+// #1 argument id: BoxID - ID of the collider
+//
+// #2 argument collider: Collider - the new shape
+//
+// #1 return: bool - false if there is no collider with the ID
+func (world *World) SetCollider(id BoxID, collider Collider) bool {
+	i := world.getBoxIndex(&world.collider_indexes, len(world.Colliders), id)
+	if i == -1 {
+		return false
+	}
+
+	world.collider_grid.remove(i)
+	world.Colliders[i].Collider = collider
+	world.Colliders[i].BoundingBox = collider.GetBoundingBox()
+	world.collider_grid.insert(i, world.Colliders[i].BoundingBox)
+
+	return true
+}
+
+// Gets a collider by its ID
+//
+// #1 argument id: BoxID - ID of the collider
+//
+// #1 return: WorldCollider - copy of the collider
+//
+// #2 return: bool - false if there is no collider with the ID
+func (world *World) GetCollider(id BoxID) (WorldCollider, bool) {
+	i := world.getBoxIndex(&world.collider_indexes, len(world.Colliders), id)
+	if i == -1 {
+		return WorldCollider{}, false
+	}
+
+	return world.Colliders[i], true
+}
+
+// Enables or disables collisions of a collider
+//
+// #1 argument id: BoxID - ID of the collider
+//
+// #2 argument enabled: bool - if the collider should collide
+//
+// #1 return: bool - false if there is no collider with the ID
+func (world *World) SetColliderEnabled(id BoxID, enabled bool) bool {
+	i := world.getBoxIndex(&world.collider_indexes, len(world.Colliders), id)
+	if i == -1 {
+		return false
+	}
+
+	world.Colliders[i].Disabled = !enabled
+
+	return true
+}
+
+// Sets if the player can interact with interactable boxes through a collider
+//
+// #1 argument id: BoxID - ID of the collider
+//
+// #2 argument see_through: bool - if the collider doesn't block interaction
+//
+// #1 return: bool - false if there is no collider with the ID
+func (world *World) SetColliderSeeThrough(id BoxID, see_through bool) bool {
+	i := world.getBoxIndex(&world.collider_indexes, len(world.Colliders), id)
+	if i == -1 {
+		return false
+	}
+
+	world.Colliders[i].SeeThrough = see_through
+
+	return true
+}
+
+// Checks if a collider can collide with the player this frame
+//
+// #1 argument i: int - index of the collider
+//
+// #1 return: bool - true if the collider is enabled and close enough to the player
+func (world *World) isColliderActive(i int) bool {
+	return !world.Colliders[i].Disabled &&
+		world.isInCalculationDistance(world.Colliders[i].BoundingBox, world.Colliders[i].AlwaysActive)
+}
+
+// Checks if an area collides with any active collider
+//
+// #1 argument area: rl.BoundingBox - the area
+//
+// #1 return: bool - true if there is a collision
+func (world *World) isColliding(area rl.BoundingBox) bool {
+	for _, i := range world.queryColliders(area) {
+		if world.isColliderActive(i) && rl.CheckCollisionBoxes(area, world.Colliders[i].BoundingBox) && world.Colliders[i].Collider.CheckCollisionBox(area) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Gets the shape of a bounding box
+//
+// #1 return: Collider - the oriented box if the box has one, otherwise the bounding box
+func (box *CollisionBox) getCollider() Collider {
+	if box.IsOriented {
+		return box.Oriented
+	}
+
+	return BoxCollider{Box: box.BoundingBox}
+}
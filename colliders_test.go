@@ -0,0 +1,126 @@
+package rlfp
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"github.com/chewxy/math32"
+)
+
+// Splits a quad into two triangles
+//
+// #1 argument a: rl.Vector3 - first corner of the quad
+//
+// #2 argument b: rl.Vector3 - second corner of the quad
+//
+// #3 argument c: rl.Vector3 - third corner of the quad
+//
+// #4 argument d: rl.Vector3 - fourth corner of the quad
+//
+// #1 return: [][3]rl.Vector3 - the triangles
+func getTestQuad(a rl.Vector3, b rl.Vector3, c rl.Vector3, d rl.Vector3) [][3]rl.Vector3 {
+	return [][3]rl.Vector3{{a, b, c}, {a, c, d}}
+}
+
+// Creates a world with a mesh floor of 20x20 at Y 0 and the ground far below it
+//
+// #1 argument position: rl.Vector3 - player's position
+//
+// #1 return: *World - the new world
+//
+// #2 return: *MeshCollider - the floor
+func newMeshTestWorld(position rl.Vector3) (*World, *MeshCollider) {
+	world := newTestWorld(position)
+	world.Ground = -100.
+
+	triangles := [][3]rl.Vector3{}
+	for x := float32(-10.); x < 10.; x++ {
+		for z := float32(-10.); z < 10.; z++ {
+			triangles = append(triangles, getTestQuad(rl.Vector3{X: x, Y: 0., Z: z}, rl.Vector3{X: x + 1., Y: 0., Z: z}, rl.Vector3{X: x + 1., Y: 0., Z: z + 1.}, rl.Vector3{X: x, Y: 0., Z: z + 1.})...)
+		}
+	}
+	// Ramp from X 2 to 6 rising to Y 1
+	triangles = append(triangles, getTestQuad(rl.Vector3{X: 2., Y: 0., Z: -3.}, rl.Vector3{X: 6., Y: 1., Z: -3.}, rl.Vector3{X: 6., Y: 1., Z: 3.}, rl.Vector3{X: 2., Y: 0., Z: 3.})...)
+
+	mesh := &MeshCollider{}
+	mesh.Init(triangles)
+	world.AddCollider(mesh)
+
+	return world, mesh
+}
+
+// The player lands on a mesh and walks up and down a ramp of its triangles
+func TestMeshCollider(t *testing.T) {
+	world, _ := newMeshTestWorld(rl.Vector3{X: 0., Y: 3., Z: 0.})
+	stepTestWorld(world, 120, [ControlCount]bool{})
+	if math32.Abs(world.Player.BoundingBox.Min.Y) > .01 {
+		t.Fatalf("player landed at Y %g, want on the mesh at Y 0", world.Player.BoundingBox.Min.Y)
+	}
+
+	for i := 0; i < 180; i++ {
+		stepTestWorld(world, 1, getTestInputs(ControlBackward))
+		box := world.Player.BoundingBox
+		if box.Max.X > 2.1 && box.Max.X < 5.9 && math32.Abs(box.Min.Y-(box.Max.X-2.)/4.) > .02 {
+			t.Fatalf("player is at Y %g at X %g, want on the ramp at Y %g", box.Min.Y, box.Max.X, (box.Max.X-2.)/4.)
+		}
+	}
+	if world.Player.BoundingBox.Min.X < 7. || math32.Abs(world.Player.BoundingBox.Min.Y) > .01 {
+		t.Errorf("player walked to %v, want past the ramp on the mesh", world.Player.BoundingBox.Min)
+	}
+}
+
+// The player stands on a sphere and a capsule stops the jump
+func TestSphereAndCapsuleColliders(t *testing.T) {
+	world, _ := newMeshTestWorld(rl.Vector3{X: 5., Y: 5., Z: 5.})
+	world.AddCollider(SphereCollider{Center: rl.Vector3{X: 5., Y: 0., Z: 5.}, Radius: 1.5})
+	stepTestWorld(world, 120, [ControlCount]bool{})
+	if math32.Abs(world.Player.BoundingBox.Min.Y-1.5) > .01 {
+		t.Errorf("player is at Y %g, want on the sphere at Y 1.5", world.Player.BoundingBox.Min.Y)
+	}
+
+	world, _ = newMeshTestWorld(rl.Vector3{X: -5., Y: 1., Z: -5.})
+	world.AddCollider(CapsuleCollider{Start: rl.Vector3{X: -7., Y: 2.5, Z: -5.}, End: rl.Vector3{X: -3., Y: 2.5, Z: -5.}, Radius: .2})
+	stepTestWorld(world, 30, [ControlCount]bool{})
+	max_y := float32(0.)
+	for i := 0; i < 60; i++ {
+		stepTestWorld(world, 1, getTestInputs(ControlJump))
+		max_y = math32.Max(max_y, world.Player.BoundingBox.Max.Y)
+	}
+	if max_y > 2.3 || max_y < 2.29 {
+		t.Errorf("player jumped to Y %g, want stopped at the capsule at Y 2.3", max_y)
+	}
+}
+
+// Rays hit the mesh and the capsule at the right points
+func TestColliderRays(t *testing.T) {
+	_, mesh := newMeshTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	ray := rl.Ray{Position: rl.Vector3{X: 0., Y: 5., Z: 0.}, Direction: rl.Vector3{X: 0., Y: -1., Z: 0.}}
+
+	if collision := mesh.GetRayCollision(ray); !collision.Hit || collision.Distance != 5. {
+		t.Errorf("ray hit the mesh at %+v, want at distance 5", collision)
+	}
+
+	capsule := CapsuleCollider{Start: rl.Vector3{X: -1., Y: 0., Z: 0.}, End: rl.Vector3{X: 1., Y: 0., Z: 0.}, Radius: .5}
+	if collision := capsule.GetRayCollision(ray); !collision.Hit || collision.Distance != 4.5 || collision.Normal != (rl.Vector3{X: 0., Y: 1., Z: 0.}) {
+		t.Errorf("ray hit the capsule at %+v, want the top at distance 4.5", collision)
+	}
+
+	ray.Position.X = 2.
+	if collision := capsule.GetRayCollision(ray); collision.Hit {
+		t.Errorf("ray next to the capsule hit it at %+v", collision)
+	}
+}
+
+// Colliders get IDs and can be removed
+func TestColliderIDs(t *testing.T) {
+	world, _ := newMeshTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	sphere := world.AddCollider(SphereCollider{Center: rl.Vector3{X: 5., Y: 0., Z: 5.}, Radius: 1.})
+
+	if collider, ok := world.GetCollider(sphere); !ok || collider.ID != sphere {
+		t.Fatalf("got %+v for the sphere", collider)
+	}
+	if !world.RemoveCollider(sphere) || world.RemoveCollider(sphere) || len(world.Colliders) != 1 {
+		t.Error("collider wasn't removed exactly once")
+	}
+}
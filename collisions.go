@@ -2,193 +2,284 @@ package rlfp
 
 import (
 	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"github.com/chewxy/math32"
 )
 
-// Checks if the player is colliding with a bounding box after moving by world.Player.OffsetNextFrame.X on the X axis
+// How many times the player can hit something and slide along it in one frame
+const playerSlideIterations = 4
+
+// First thing hit by a moving box
+type SweepHit struct {
+	// If the box hit something
+	Hit bool
+	// Part of the offset the box moved before the hit, from 0 to 1, 1 when nothing was hit
+	Time float32
+	// Normal of the hit surface, pointing away from it
+	Normal rl.Vector3
+	// Index of the bounding box that was hit, -1 when something else or nothing was hit
+	Index int
+	// Index of the collider that was hit, -1 when something else or nothing was hit
+	Collider int
+}
+
+// Finds when a moving box hits another box
 //
-// #1 return: int - the index of the bounding box that is colliding with the player (if there is no collision, returns -1)
+// #1 argument box: rl.BoundingBox - the moving box
 //
-// #2 return: bool - true if world.Player.OffsetNextFrame.X is positive
-func (world *World) checkPlayerCollisionsXNextFrame() (int, bool) {
-	bounding_box := world.Player.BoundingBox
-
-	// Move bounding_box by world.Player.OffsetNextFrame.X on the X axis
-	if world.Player.OffsetNextFrame.X > 0 {
-		bounding_box.Max.X += world.Player.OffsetNextFrame.X
-	} else {
-		bounding_box.Min.X += world.Player.OffsetNextFrame.X
-	}
+// #2 argument offset: rl.Vector3 - how far the box moves
+//
+// #3 argument target: rl.BoundingBox - the box that can be hit
+//
+// #1 return: float32 - part of the offset the box moves before the hit, from 0 to 1
+//
+// #2 return: rl.Vector3 - normal of the hit side of the target
+//
+// #3 return: bool - false if the box doesn't hit the target or is already inside it
+func SweepBoundingBox(box rl.BoundingBox, offset rl.Vector3, target rl.BoundingBox) (float32, rl.Vector3, bool) {
+	entry := math32.Inf(-1)
+	exit := math32.Inf(1)
+	normal := rl.Vector3{X: 0., Y: 0., Z: 0.}
+
+	for _, axis := range [3]int{axisX, axisY, axisZ} {
+		distance := getAxis(offset, axis)
+		box_min, box_max := getAxis(box.Min, axis), getAxis(box.Max, axis)
+		target_min, target_max := getAxis(target.Min, axis), getAxis(target.Max, axis)
+
+		// Not moving on the axis, the boxes have to overlap on it the whole time
+		if distance == 0. {
+			if box_max <= target_min || box_min >= target_max {
+				return 1., rl.Vector3{X: 0., Y: 0., Z: 0.}, false
+			}
+			continue
+		}
 
-	// Check if bounding_box is colliding with another bounding box
-	for i := range world.BoundingBoxes {
-		if getDistance(world.Player.Position.X, world.Player.Position.Z,
-			world.BoundingBoxes[i].Min.X, world.BoundingBoxes[i].Min.Z) <= world.CalculationDistance &&
-			rl.CheckCollisionBoxes(bounding_box, world.BoundingBoxes[i]) {
+		var enter, leave float32
+		if distance > 0. {
+			enter = (target_min - box_max) / distance
+			leave = (target_max - box_min) / distance
+		} else {
+			enter = (target_max - box_min) / distance
+			leave = (target_min - box_max) / distance
+		}
 
-			return i, world.Player.OffsetNextFrame.X > 0
+		if enter > entry {
+			entry = enter
+			normal = setAxis(rl.Vector3{X: 0., Y: 0., Z: 0.}, axis, -math32.Copysign(1., distance))
 		}
+		exit = math32.Min(exit, leave)
+	}
+
+	if entry >= exit || entry < 0. || entry > 1. {
+		return 1., rl.Vector3{X: 0., Y: 0., Z: 0.}, false
 	}
 
-	return -1, false
+	return entry, normal, true
 }
 
-// Checks if the player is colliding with a bounding box after moving by world.Player.OffsetNextFrame.X on the X axis
+// Finds the first bounding box, collider or the ground the player hits when moving by an offset, shapes the player is already inside are ignored
 //
-// #1 argument y: float32 - the value to set to the Y axis of the  player's bounding box
+// #1 argument offset: rl.Vector3 - how far the player moves
 //
-// #1 return: bool - if there is a collision with a bounding box
-func (world *World) checkPlayerCollisionsXYNextFrame(y float32) bool {
-	bounding_box := world.Player.BoundingBox
-
-	// Move bounding_box by world.Player.OffsetNextFrame.X on the X axis
-	if world.Player.OffsetNextFrame.X > 0 {
-		bounding_box.Max.X += world.Player.OffsetNextFrame.X
-	} else {
-		bounding_box.Min.X += world.Player.OffsetNextFrame.X - world.FrameTime
-	}
-
-	bounding_box.Min.Y = y
-	bounding_box.Max.Y = y + world.Player.Scale.Y
-
-	// Check if bounding_box is colliding with another bounding box
-	for i := range world.BoundingBoxes {
-		if getDistance(world.Player.Position.X, world.Player.Position.Z,
-			world.BoundingBoxes[i].Min.X, world.BoundingBoxes[i].Min.Z) <= world.CalculationDistance &&
-			rl.CheckCollisionBoxes(bounding_box, world.BoundingBoxes[i]) {
-
-			return true
-		}
-	}
-
-	return false
+// #1 return: SweepHit - the first hit
+func (world *World) SweepPlayer(offset rl.Vector3) SweepHit {
+	return world.sweepBox(world.Player.BoundingBox, offset)
 }
 
-// Checks if the player is colliding with a bounding box after moving by world.Player.OffsetNextFrame.Y on the Y axis
+// Finds the first bounding box, collider or the ground a box hits when moving by an offset, shapes the box is already inside are ignored
 //
-// #1 return: int - the index of the bounding box that is colliding with the player (if there is no collision, returns -1)
+// #1 argument box: rl.BoundingBox - the moving box
 //
-// #2 return: bool - true if world.Player.OffsetNextFrame.Y is positive
-func (world *World) checkPlayerCollisionsYNextFrame() (int, bool) {
-	bounding_box := world.Player.BoundingBox
+// #2 argument offset: rl.Vector3 - how far the box moves
+//
+// #1 return: SweepHit - the first hit
+func (world *World) sweepBox(box rl.BoundingBox, offset rl.Vector3) SweepHit {
+	hit := SweepHit{Hit: false, Time: 1., Normal: rl.Vector3{X: 0., Y: 0., Z: 0.}, Index: -1, Collider: -1}
 
-	// Move bounding_box by world.Player.OffsetNextFrame.Y on the Y axis
-	if world.Player.OffsetNextFrame.Y > 0 {
-		bounding_box.Max.Y += world.Player.OffsetNextFrame.Y
-	} else {
-		bounding_box.Min.Y += world.Player.OffsetNextFrame.Y
+	// Check the ground
+	if offset.Y < 0. && box.Min.Y >= world.Ground && box.Min.Y+offset.Y < world.Ground {
+		hit = SweepHit{Hit: true, Time: (world.Ground - box.Min.Y) / offset.Y, Normal: rl.Vector3{X: 0., Y: 1., Z: 0.}, Index: -1, Collider: -1}
 	}
 
-	// Check if bounding_box is colliding with another bounding box
-	for i := range world.BoundingBoxes {
-		if getDistance(world.Player.Position.X, world.Player.Position.Z,
-			world.BoundingBoxes[i].Min.X, world.BoundingBoxes[i].Min.Z) <= world.CalculationDistance &&
-			rl.CheckCollisionBoxes(bounding_box, world.BoundingBoxes[i]) {
+	// Check every bounding box and collider in the area the box moves through
+	area := rl.BoundingBox{
+		Min: rl.Vector3Min(box.Min, rl.Vector3Add(box.Min, offset)),
+		Max: rl.Vector3Max(box.Max, rl.Vector3Add(box.Max, offset)),
+	}
+	for _, i := range world.queryBoundingBoxes(area) {
+		if !world.isBoundingBoxActive(i) {
+			continue
+		}
 
-			return i, world.Player.OffsetNextFrame.Y > 0
+		if time, normal, ok := world.BoundingBoxes[i].getCollider().SweepBox(box, offset); ok && time < hit.Time {
+			hit = SweepHit{Hit: true, Time: time, Normal: normal, Index: i, Collider: -1}
 		}
 	}
+	for _, i := range world.queryColliders(area) {
+		if !world.isColliderActive(i) || !rl.CheckCollisionBoxes(area, world.Colliders[i].BoundingBox) {
+			continue
+		}
 
-	return -1, false
-}
-
-// Checks if the player is colliding with a bounding box after moving to world.Ground on the Y axis
-//
-// #1 return: int - the index of the bounding box that is colliding with the player (if there is no collision, returns -1)
-func (world *World) checkPlayerCollisionsYOnGround() int {
-	bounding_box := world.Player.BoundingBox
-
-	// Move bounding_box to world.Ground on the Y axis
-	bounding_box.Max.Y += world.Ground + world.FloatPrecision - world.Player.BoundingBox.Min.Y
-
-	// Check if bounding_box is colliding with another bounding box
-	for i := range world.BoundingBoxes {
-		if getDistance(world.Player.Position.X, world.Player.Position.Z,
-			world.BoundingBoxes[i].Min.X, world.BoundingBoxes[i].Min.Z) <= world.CalculationDistance &&
-			rl.CheckCollisionBoxes(bounding_box, world.BoundingBoxes[i]) {
-
-			return i
+		if time, normal, ok := world.Colliders[i].Collider.SweepBox(box, offset); ok && time < hit.Time {
+			hit = SweepHit{Hit: true, Time: time, Normal: normal, Index: -1, Collider: i}
 		}
 	}
 
-	return -1
+	return hit
 }
 
-// Checks if the player is colliding with a bounding box after moving by world.Player.OffsetNextFrame.Z on the Z axis
-//
-// #1 return: int - the index of the bounding box that is colliding with the player (if there is no collision, returns -1)
+// Moves the player by an offset, the player stops at everything it hits and slides along it with the rest of the offset
+// The player steps up on low obstacles and pushes pushable boxes
 //
-// #2 return: bool - true if world.Player.OffsetNextFrame.Z is positive
-func (world *World) checkPlayerCollisionsZNextFrame() (int, bool) {
-	bounding_box := world.Player.BoundingBox
+// #1 argument offset: rl.Vector3 - how far the player moves
+func (world *World) MovePlayer(offset rl.Vector3) {
+	remaining := offset
+	pushed := -1
+
+	for iteration := 0; iteration < playerSlideIterations; iteration++ {
+		if remaining.X == 0. && remaining.Y == 0. && remaining.Z == 0. {
+			return
+		}
 
-	// Move bounding_box by world.Player.OffsetNextFrame.Z on the Z axis
-	if world.Player.OffsetNextFrame.Z > 0 {
-		bounding_box.Max.Z += world.Player.OffsetNextFrame.Z
-	} else {
-		bounding_box.Min.Z += world.Player.OffsetNextFrame.Z
-	}
+		hit := world.SweepPlayer(remaining)
+		if !hit.Hit {
+			world.setPlayerBoundingBox(moveBox(world.Player.BoundingBox, remaining))
+			return
+		}
 
-	// Check if bounding_box is colliding with another bounding box
-	for i := range world.BoundingBoxes {
-		if getDistance(world.Player.Position.X, world.Player.Position.Z,
-			world.BoundingBoxes[i].Min.X, world.BoundingBoxes[i].Min.Z) <= world.CalculationDistance &&
-			rl.CheckCollisionBoxes(bounding_box, world.BoundingBoxes[i]) {
+		// Move to the hit and leave a gap of world.FloatPrecision
+		moved := rl.Vector3Scale(remaining, hit.Time)
+		world.setPlayerBoundingBox(moveBox(world.Player.BoundingBox, rl.Vector3Add(moved, rl.Vector3Scale(hit.Normal, world.FloatPrecision))))
+		remaining = rl.Vector3Subtract(remaining, moved)
+
+		if math32.Abs(hit.Normal.Y) < world.FloatPrecision {
+			if stepped, ok := world.stepPlayerUp(remaining); ok {
+				remaining = stepped
+				continue
+			}
+
+			// Push the box once per frame, the player follows it in the next iteration
+			axis := axisX
+			if hit.Normal.Z != 0. {
+				axis = axisZ
+			}
+			if hit.Index != -1 && pushed == -1 && world.pushBoundingBox(hit.Index, axis, getAxis(remaining, axis)) {
+				pushed = hit.Index
+				continue
+			}
+		}
 
-			return i, world.Player.OffsetNextFrame.Z > 0
+		// Stop falling on walkable ground or jumping at a ceiling, the player keeps falling along steep slopes
+		is_walkable := world.Player.isWalkable(hit.Normal)
+		if is_walkable || (hit.Normal.Y < 0. && world.Player.YVelocity > 0.) {
+			world.Player.YVelocity = 0.
 		}
-	}
 
-	return -1, false
+		// Walls and steep slopes stop the velocity going into them, except the box the player is pushing
+		if !is_walkable && (hit.Index == -1 || hit.Index != pushed) {
+			world.Player.HorizontalVelocity = getWallVelocity(world.Player.HorizontalVelocity, hit.Normal)
+		}
+
+		// Slide along the hit surface
+		if hit.Normal.Y > 0. && !is_walkable {
+			remaining = getSteepSlopeSlide(remaining, hit.Normal)
+		} else {
+			remaining = rl.Vector3Subtract(remaining, rl.Vector3Scale(hit.Normal, rl.Vector3DotProduct(remaining, hit.Normal)))
+		}
+	}
 }
 
-// Checks if the player is colliding with a bounding box after moving by world.Player.OffsetNextFrame.Z on the Z axis
+// Moves the player over a low obstacle the player walked into, the player is lifted by world.Player.StepHeight,
+// moved horizontally and put down on top of the obstacle
 //
-// #1 argument y: float32 - the value to set to the Y axis of the player's bounding box
+// #1 argument offset: rl.Vector3 - the rest of the player's movement
 //
-// #1 return: bool - if there is a collision with a bounding box
-func (world *World) checkPlayerCollisionsZYNextFrame(y float32) bool {
-	bounding_box := world.Player.BoundingBox
-
-	// Move bounding_box by world.Player.OffsetNextFrame.Z on the Z axis
-	if world.Player.OffsetNextFrame.Z > 0 {
-		bounding_box.Max.Z += world.Player.OffsetNextFrame.Z
-	} else {
-		bounding_box.Min.Z += world.Player.OffsetNextFrame.Z
+// #1 return: rl.Vector3 - the rest of the movement after stepping up
+//
+// #2 return: bool - true if the player stepped up
+func (world *World) stepPlayerUp(offset rl.Vector3) (rl.Vector3, bool) {
+	horizontal := rl.Vector3{X: offset.X, Y: 0., Z: offset.Z}
+	if (horizontal.X == 0. && horizontal.Z == 0.) || !world.isPlayerOnGroundNextFrame() {
+		return offset, false
+	}
+	start := world.Player.BoundingBox
+
+	// Lift the player, a ceiling can make the lift lower
+	lift := rl.Vector3{X: 0., Y: world.Player.StepHeight, Z: 0.}
+	hit := world.sweepBox(start, lift)
+	lifted := moveBox(start, rl.Vector3Scale(lift, hit.Time))
+	if hit.Hit {
+		lifted = moveBox(lifted, rl.Vector3Scale(hit.Normal, world.FloatPrecision))
 	}
 
-	bounding_box.Min.Y = y
-	bounding_box.Max.Y = y + world.Player.Scale.Y
-
-	// Check if bounding_box is colliding with another bounding box
-	for i := range world.BoundingBoxes {
-		if getDistance(world.Player.Position.X, world.Player.Position.Z,
-			world.BoundingBoxes[i].Min.X, world.BoundingBoxes[i].Min.Z) <= world.CalculationDistance &&
-			rl.CheckCollisionBoxes(bounding_box, world.BoundingBoxes[i]) {
+	// Move over the obstacle, it's higher than the step when the player can't move at all
+	hit = world.sweepBox(lifted, horizontal)
+	forward := rl.Vector3Scale(horizontal, hit.Time)
+	if rl.Vector3Length(forward) <= world.FloatPrecision {
+		return offset, false
+	}
+	moved := moveBox(lifted, forward)
+	if hit.Hit {
+		moved = moveBox(moved, rl.Vector3Scale(hit.Normal, world.FloatPrecision))
+	}
 
-			return true
-		}
+	// Put the player down, there has to be walkable ground higher than where the player started
+	drop := rl.Vector3{X: 0., Y: start.Min.Y - moved.Min.Y - world.FloatPrecision*2., Z: 0.}
+	hit = world.sweepBox(moved, drop)
+	if !hit.Hit || !world.Player.isWalkable(hit.Normal) {
+		return offset, false
+	}
+	landed := moveBox(moved, rl.Vector3Add(rl.Vector3Scale(drop, hit.Time), rl.Vector3Scale(hit.Normal, world.FloatPrecision)))
+	if landed.Min.Y <= start.Min.Y+world.FloatPrecision {
+		return offset, false
 	}
 
-	return false
+	world.setPlayerBoundingBox(landed)
+	world.Player.YVelocity = 0.
+
+	return rl.Vector3Subtract(horizontal, forward), true
 }
 
-// Checks if the player is on the ground after moving by world.FloatPrecision on the Y axis
+// Checks if the player is standing on the ground, on a bounding box or on a collider, steep slopes don't count
 //
 // #1 return: bool - true if the player is on the ground
 func (world *World) isPlayerOnGroundNextFrame() bool {
+	// Thin box right under the player
 	bounding_box := world.Player.BoundingBox
-
-	// Move bounding_box by world.FloatPrecision on the Y axis
-	bounding_box.Min.Y -= world.FloatPrecision
+	bounding_box.Max.Y = bounding_box.Min.Y
+	bounding_box.Min.Y -= world.getGroundProbeDistance()
 
 	// Check if bounding_box is on the ground
-	if bounding_box.Min.Y <= world.Ground && bounding_box.Max.Y > world.Ground {
+	if bounding_box.Min.Y <= world.Ground && world.Player.BoundingBox.Max.Y > world.Ground {
 		return true
 	}
-	// Check if bounding_box is colliding with a bounding box
-	if i, _ := world.checkPlayerCollisionsYNextFrame(); i != -1 {
-		return true
+	// Check if bounding_box is colliding with a bounding box or a collider
+	if !world.isPlayerBlocked(bounding_box, -1) {
+		return false
+	}
+
+	// Check if the surface under the player isn't too steep, the player can also be slightly inside what they stand on
+	ground := world.getPlayerGround()
+	return !ground.Hit || world.Player.isWalkable(ground.Normal)
+}
+
+// Removes the part of a horizontal velocity going into a wall
+//
+// #1 argument velocity: rl.Vector2 - the velocity, X is on the X axis and Y on the Z axis
+//
+// #2 argument normal: rl.Vector3 - normal of the wall, only its horizontal part is used
+//
+// #1 return: rl.Vector2 - the velocity along the wall
+func getWallVelocity(velocity rl.Vector2, normal rl.Vector3) rl.Vector2 {
+	wall := rl.Vector2{X: normal.X, Y: normal.Z}
+	if rl.Vector2Length(wall) < 1e-6 {
+		return velocity
+	}
+	wall = rl.Vector2Normalize(wall)
+
+	if into := rl.Vector2DotProduct(velocity, wall); into < 0. {
+		return rl.Vector2Subtract(velocity, rl.Vector2Scale(wall, into))
 	}
 
-	return false
+	return velocity
 }
@@ -0,0 +1,64 @@
+package rlfp
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"github.com/chewxy/math32"
+)
+
+// A long move stops at a thin wall instead of going through it and slides along it
+func TestMovePlayerDoesntTunnel(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	stepTestWorld(world, 30, [ControlCount]bool{})
+	world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: -5.1, Y: 0., Z: -5.}, Max: rl.Vector3{X: -5., Y: 3., Z: 5.}})
+
+	world.MovePlayer(rl.Vector3{X: -20., Y: 0., Z: -3.})
+	if math32.Abs(world.Player.BoundingBox.Min.X+5.) > .01 {
+		t.Errorf("player moved to X %g, want stopped at the wall at X -5", world.Player.BoundingBox.Min.X)
+	}
+	if math32.Abs(world.Player.Position.Z+3.) > .01 {
+		t.Errorf("player slid to Z %g, want -3", world.Player.Position.Z)
+	}
+}
+
+// Frames longer than the fall through a thin platform still land on it
+func TestFallWithLongFrames(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 20., Y: 50., Z: 20.})
+	world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: 19., Y: 5., Z: 19.}, Max: rl.Vector3{X: 21., Y: 5.05, Z: 21.}})
+
+	for i := 0; i < 5; i++ {
+		world.Step(1., [ControlCount]bool{}, rl.Vector2{X: 0., Y: 0.})
+	}
+	if math32.Abs(world.Player.BoundingBox.Min.Y-5.05) > .01 {
+		t.Errorf("player fell to Y %g, want on the platform at Y 5.05", world.Player.BoundingBox.Min.Y)
+	}
+}
+
+// A ceiling stops the jump
+func TestCeilingStopsJump(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 10., Y: 1., Z: 10.})
+	world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: 9., Y: 2.2, Z: 9.}, Max: rl.Vector3{X: 11., Y: 3., Z: 11.}})
+	stepTestWorld(world, 30, [ControlCount]bool{})
+
+	max_y := float32(0.)
+	for i := 0; i < 60; i++ {
+		stepTestWorld(world, 1, getTestInputs(ControlJump))
+		max_y = math32.Max(max_y, world.Player.BoundingBox.Max.Y)
+	}
+	if max_y > 2.2 {
+		t.Errorf("player jumped into the ceiling to Y %g", max_y)
+	}
+}
+
+// The player steps up on a box lower than the step height
+func TestStepUp(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: -3., Y: 0., Z: -1.}, Max: rl.Vector3{X: -2., Y: .3, Z: 1.}})
+
+	stepTestWorld(world, 60, getTestInputs(ControlForward))
+	if math32.Abs(world.Player.BoundingBox.Min.Y-.3) > .01 || world.Player.BoundingBox.Min.X > -2. {
+		t.Errorf("player is at %v, want on the step", world.Player.BoundingBox.Min)
+	}
+}
@@ -14,17 +14,59 @@ type World struct {
 	FrameTime     float32
 	LastFrameTime float32
 	// Boxes with collisions
-	BoundingBoxes []rl.BoundingBox
+	// The boxes, trigger boxes, interactable boxes and colliders are found through spatial grids, move or resize them with
+	// the world's methods (e.g. world.SetBoundingBoxBounds) or call world.RebuildSpatialGrids after changing them directly
+	BoundingBoxes []CollisionBox
 	// Boxes that activate when a player walks into them
 	TriggerBoxes []TriggerBox
 	// Boxes that activate when a player presses a key when looking at them
 	InteractableBoxes []InteractableBox
+	// Static shapes with collisions, e.g. spheres, capsules and triangle meshes of the level
+	Colliders []WorldCollider
 	// Minimum value for working with floats
 	FloatPrecision float32
-	// Distance where the player has to be in to update certain elements in the world
+	// Distance from the player to the closest point of a box, where the box is still updated
+	// It used to be squared (40000 for 200 units), now it's the plain distance, squared values set by older code have to be rooted
 	CalculationDistance float32
 	// For interactable boxes, so they don't update their state every frame
 	AlreadySetInteractStates bool
+	// Opt-in simulation in fixed ticks with render interpolation
+	FixedTimestep FixedTimestep
+	// Frames passed to world.Advance are recorded here when it's not nil
+	Recording *Recording
+	// Size of one cell of the spatial grids used for finding nearby boxes, world.RebuildSpatialGrids has to be called after changing it
+	GridCellSize float32
+	// Last ID given to a box
+	NextBoxID BoxID
+	// Maximum number of events kept until world.PollEvents is called, 0 for no limit
+	MaxEvents int
+
+	bounding_box_grid         spatialGrid
+	trigger_box_grid          spatialGrid
+	interactable_box_grid     spatialGrid
+	collider_grid             spatialGrid
+	bounding_box_query        []int
+	trigger_box_query         []int
+	interactable_box_query    []int
+	collider_query            []int
+	active_trigger_boxes      []int
+	active_interactable_boxes []int
+	kinematic_boxes           []int
+	dynamic_boxes             []int
+	bounding_box_indexes      map[BoxID]int
+	trigger_box_indexes       map[BoxID]int
+	interactable_box_indexes  map[BoxID]int
+	collider_indexes          map[BoxID]int
+	events                    []Event
+	trigger_events            []Event
+	trigger_callbacks         map[BoxID]TriggerCallbacks
+	// Boxes whose one frame states were set in the ticks of the current world.StepFixed
+	latched_trigger_boxes      []BoxID
+	latched_interactable_boxes []BoxID
+	// How far the player could interact in the last update, shortened by solid boxes in the way
+	interact_reach float32
+	// Closest interactable box hit by the mouse ray in the last update
+	focused_interactable InteractableFocus
 }
 
 // Initializes default values for the world
@@ -36,10 +78,11 @@ func (world *World) Init(ground float32) {
 	world.Gravity = 15.
 	world.FrameTime = 0.
 	world.FloatPrecision = .0001
-	// The actual distance is math32.Sqrt(world.CalculationDistance)
-	// This is because the program is faster without square rooting and it has the same effect
-	world.CalculationDistance = 40000.
+	world.CalculationDistance = 200.
 	world.AlreadySetInteractStates = false
+	world.FixedTimestep.Init()
+	world.GridCellSize = 4.
+	world.MaxEvents = 1024
 }
 
 // Creates a new world with the player at the specified position, should be called when loading a save
@@ -51,30 +94,93 @@ func (world *World) Init(ground float32) {
 // #3 argument is_crouching: bool - if the player is crouching
 func (world *World) New(position rl.Vector3, rotation rl.Vector2, is_crouching bool) {
 	world.Player.New(position, rotation, is_crouching)
-	world.BoundingBoxes = []rl.BoundingBox{}
+	world.BoundingBoxes = []CollisionBox{}
 	world.TriggerBoxes = []TriggerBox{}
 	world.InteractableBoxes = []InteractableBox{}
+	world.Colliders = []WorldCollider{}
+	world.events = nil
+	world.trigger_events = nil
+	world.trigger_callbacks = nil
+	world.focused_interactable = InteractableFocus{}
+	world.ResetInterpolation()
+	world.RebuildSpatialGrids()
 }
 
-// Adds a new bounding box to the world
+// Updates every value in the world struct, should be called every frame
+// Reads the inputs from world.Player.Input (KeyboardMouseInput when it's nil) and passes them to world.Advance
+// The window size isn't used anymore, it's kept so older code still compiles and any values can be passed
 //
-// #1 argument box: rl.BoundingBox - bounding box to add
-func (world *World) AddBoundingBox(box rl.BoundingBox) {
-	world.BoundingBoxes = append(world.BoundingBoxes, box)
+// #1 argument windowWidth: int32 - width of the window (unused, the interaction ray is cast from the center of the camera)
+//
+// #2 argument windowHeight: int32 - height of the window (unused, the interaction ray is cast from the center of the camera)
+func (world *World) Update(windowWidth, windowHeight int32) {
+	frame_time := rl.GetFrameTime()
+	inputs, mouse_delta := world.Player.getInput().Poll(&world.Player, frame_time)
+	world.Advance(frame_time, inputs, mouse_delta)
 }
 
-// Updates every value in the world struct, should be called every frame
+// Advances the world by a rendered frame, in fixed ticks when world.FixedTimestep.Enabled is true, otherwise in one world.Step
 //
-// #1 argument windowWidth: int32 - width of the window
+// #1 argument frame_time: float32 - time of the frame in seconds, clamped to 0 - 1 second, NaN is 0
 //
-// #2 argument windowHeight: int32 - height of the window
-func (world *World) Update(windowWidth, windowHeight int32) {
-	world.LastFrameTime = world.FrameTime
-	world.FrameTime = rl.GetFrameTime()
-	if world.FrameTime > 1. {
-		world.FrameTime = 1.
+// #2 argument inputs: [ControlCount]bool - state of every control, indexed the same way as world.Player.CurrentInputs
+//
+// #3 argument mouse_delta: rl.Vector2 - mouse movement since the last frame
+func (world *World) Advance(frame_time float32, inputs [ControlCount]bool, mouse_delta rl.Vector2) {
+	frame_time = clampFrameTime(frame_time)
+	if world.FixedTimestep.Enabled {
+		world.StepFixed(frame_time, inputs, mouse_delta)
+	} else {
+		world.Step(frame_time, inputs, mouse_delta)
+	}
+
+	if world.Recording != nil {
+		world.recordFrame(frame_time, inputs, mouse_delta)
 	}
-	world.UpdatePlayer()
+}
+
+// Advances the world by one frame without touching raylib's window state, can be used for simulations and tests
+//
+// #1 argument dt: float32 - time of the frame in seconds, clamped to 0 - 1 second, NaN is 0
+//
+// #2 argument inputs: [ControlCount]bool - state of every control, indexed the same way as world.Player.CurrentInputs
+//
+// #3 argument mouse_delta: rl.Vector2 - mouse movement since the last frame
+func (world *World) Step(dt float32, inputs [ControlCount]bool, mouse_delta rl.Vector2) {
+	world.LastFrameTime = world.FrameTime
+	world.FrameTime = clampFrameTime(dt)
+	world.Player.CurrentInputs = inputs
+	world.UpdateKinematicBoxes()
+	world.UpdateRigidBoxes()
+	world.StepPlayer(mouse_delta)
 	world.UpdateTriggerBoxes()
-	world.UpdateInteractableBoxes(windowWidth, windowHeight)
+	world.StepInteractableBoxes(world.Player.GetLookRay())
+}
+
+// Clamps a frame time, so a negative or NaN frame time doesn't move the world backwards or break it for good
+//
+// #1 argument frame_time: float32 - time of the frame in seconds
+//
+// #1 return: float32 - the frame time clamped to 0 - 1 second, 0 for NaN
+func clampFrameTime(frame_time float32) float32 {
+	// NaN fails every comparison
+	if !(frame_time > 0.) {
+		return 0.
+	}
+	if frame_time > 1. {
+		return 1.
+	}
+
+	return frame_time
+}
+
+// Checks if a box is close enough to the player to be updated
+//
+// #1 argument box: rl.BoundingBox - the box
+//
+// #2 argument always_active: bool - if the box ignores world.CalculationDistance
+//
+// #1 return: bool - true if the box should be updated
+func (world *World) isInCalculationDistance(box rl.BoundingBox, always_active bool) bool {
+	return always_active || getBoxDistanceSquared(world.Player.Position, box) <= world.CalculationDistance*world.CalculationDistance
 }
@@ -0,0 +1,147 @@
+package rlfp
+
+import (
+	"math"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Creates a world with the ground at 0 and the player standing at a position
+//
+// #1 argument position: rl.Vector3 - player's position
+//
+// #1 return: *World - the new world
+func newTestWorld(position rl.Vector3) *World {
+	world := &World{}
+	world.Init(0.)
+	world.New(position, rl.Vector2{X: 0., Y: 0.}, false)
+
+	return world
+}
+
+// Gets the inputs with some controls held
+//
+// #1 argument controls: ...int - the held controls
+//
+// #1 return: [ControlCount]bool - the inputs
+func getTestInputs(controls ...int) [ControlCount]bool {
+	inputs := [ControlCount]bool{}
+	for _, control := range controls {
+		inputs[control] = true
+	}
+
+	return inputs
+}
+
+// Steps a world by frames of 1/60 of a second with the same inputs and no mouse movement
+//
+// #1 argument world: *World - the world
+//
+// #2 argument frames: int - number of frames
+//
+// #3 argument inputs: [ControlCount]bool - the inputs
+func stepTestWorld(world *World, frames int, inputs [ControlCount]bool) {
+	for i := 0; i < frames; i++ {
+		world.Step(1./60., inputs, rl.Vector2{X: 0., Y: 0.})
+	}
+}
+
+// The player walks into a trigger box by world.Step without a window
+func TestStepMovesPlayerWithoutWindow(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	world.AddTriggerBox(rl.BoundingBox{Min: rl.Vector3{X: -5., Y: 0., Z: -1.}, Max: rl.Vector3{X: -4., Y: 1., Z: 1.}})
+
+	triggered := 0
+	inputs := getTestInputs(ControlForward)
+	for i := 0; i < 120; i++ {
+		world.Step(1./60., inputs, rl.Vector2{X: 0., Y: 0.})
+		if world.TriggerBoxes[0].Triggered {
+			triggered++
+		}
+	}
+
+	if world.Player.Position.X > -5. {
+		t.Errorf("player walked to X %g, want past -5", world.Player.Position.X)
+	}
+	if world.Player.BoundingBox.Min.Y < 0. {
+		t.Errorf("player fell under the ground to Y %g", world.Player.BoundingBox.Min.Y)
+	}
+	if triggered != 1 {
+		t.Errorf("trigger box was triggered in %d frames, want 1", triggered)
+	}
+}
+
+// Two worlds stepped with the same frames end in the same state
+func TestStepIsDeterministic(t *testing.T) {
+	worlds := [2]*World{newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.}), newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})}
+	for _, world := range worlds {
+		world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: -3., Y: 0., Z: -1.}, Max: rl.Vector3{X: -2., Y: .3, Z: 1.}})
+	}
+
+	for i := 0; i < 200; i++ {
+		inputs := getTestInputs(ControlForward)
+		inputs[ControlJump] = i%40 == 5
+		for _, world := range worlds {
+			world.Step(1./float32(30+i%50), inputs, rl.Vector2{X: float32(i % 7), Y: 0.})
+		}
+	}
+
+	if worlds[0].Player.Position != worlds[1].Player.Position || worlds[0].Player.Rotation != worlds[1].Player.Rotation {
+		t.Errorf("same frames gave different positions %v and %v", worlds[0].Player.Position, worlds[1].Player.Position)
+	}
+}
+
+// Negative and NaN frame times don't move the player and a too long frame time is clamped
+func TestStepInvalidFrameTime(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: -3., Y: 0., Z: -1.}, Max: rl.Vector3{X: -2., Y: 1., Z: 1.}})
+	stepTestWorld(world, 30, [ControlCount]bool{})
+	position := world.Player.Position
+
+	for _, dt := range []float32{-1e9, -1., float32(math.NaN())} {
+		world.Step(dt, getTestInputs(ControlForward), rl.Vector2{X: 0., Y: 0.})
+		if world.FrameTime != 0. || world.Player.Position != position {
+			t.Errorf("frame time %g moved the player from %v to %v", dt, position, world.Player.Position)
+		}
+	}
+
+	world.Step(float32(math.Inf(1)), [ControlCount]bool{}, rl.Vector2{X: 0., Y: 0.})
+	if world.FrameTime != 1. {
+		t.Errorf("infinite frame time was clamped to %g, want 1", world.FrameTime)
+	}
+}
+
+// Boxes are culled by the distance to their closest point
+func TestCalculationDistance(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	world.CalculationDistance = 10.
+	position := world.Player.Position
+
+	boxes := map[rl.BoundingBox]bool{
+		{Min: rl.Vector3{X: -500., Y: -1., Z: -500.}, Max: rl.Vector3{X: 500., Y: 0., Z: 500.}}:                                                   true,
+		{Min: rl.Vector3{X: position.X + 9.5, Y: position.Y, Z: position.Z}, Max: rl.Vector3{X: position.X + 11., Y: position.Y, Z: position.Z}}:  true,
+		{Min: rl.Vector3{X: position.X + 10.5, Y: position.Y, Z: position.Z}, Max: rl.Vector3{X: position.X + 11., Y: position.Y, Z: position.Z}}: false,
+		{Min: rl.Vector3{X: position.X, Y: position.Y + 10.5, Z: position.Z}, Max: rl.Vector3{X: position.X, Y: position.Y + 11., Z: position.Z}}: false,
+	}
+	for box, active := range boxes {
+		if world.isInCalculationDistance(box, false) != active {
+			t.Errorf("box %v is active %t, want %t", box, !active, active)
+		}
+		if !world.isInCalculationDistance(box, true) {
+			t.Errorf("always active box %v isn't active", box)
+		}
+	}
+}
+
+// The player stands on a floor which is larger than the calculation distance
+func TestStandOnLargeFloor(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 2., Z: 0.})
+	world.Ground = -100.
+	world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: -500., Y: 0., Z: -500.}, Max: rl.Vector3{X: 500., Y: 1., Z: 500.}})
+
+	stepTestWorld(world, 120, [ControlCount]bool{})
+	if world.Player.BoundingBox.Min.Y < .99 {
+		t.Errorf("player fell through the floor to Y %g", world.Player.BoundingBox.Min.Y)
+	}
+}
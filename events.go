@@ -0,0 +1,69 @@
+package rlfp
+
+// Types of events in world's event queue
+const (
+	// The entity walked into a trigger box
+	EventTriggerEnter = iota
+	// The entity is still inside a trigger box, sent every frame after EventTriggerEnter
+	EventTriggerStay
+	// The entity left a trigger box or the box was disabled or removed while the entity was inside
+	EventTriggerExit
+	// The player interacted with an interactable box, sent when a box with a hold duration is held for the whole duration
+	EventInteract
+	// The player started holding the interact key on an interactable box with a hold duration
+	EventInteractStarted
+	// The player held the interact key on an interactable box for its whole hold duration, sent together with EventInteract
+	EventInteractCompleted
+	// The player released the interact key, looked away or left the reach before the hold duration passed
+	EventInteractCancelled
+	// A kinematic box couldn't push the entity out without squashing it into another box, so the box stopped for the frame
+	EventSquash
+)
+
+// Kinds of entities that cause events
+const (
+	EntityPlayer = iota
+)
+
+// Something in the world that causes events
+type Entity struct {
+	// Kind of the entity (EntityPlayer)
+	Kind int
+	// ID of the entity, 0 for the player
+	ID BoxID
+}
+
+// Event that happened during a world update
+type Event struct {
+	// Type of the event (EventTriggerEnter, EventTriggerStay, EventTriggerExit, EventInteract, EventInteractStarted, EventInteractCompleted, EventInteractCancelled, EventSquash)
+	Type int
+	// ID of the box that sent the event
+	BoxID BoxID
+	// The entity that caused the event
+	Entity Entity
+	// world.FrameTime of the update when the event happened
+	FrameTime float32
+	// Name of the verb the player used, for interaction events
+	Verb string
+}
+
+// Takes every event from the queue, should be called after world.Update
+//
+// #1 return: []Event - the events in the order they happened
+func (world *World) PollEvents() []Event {
+	events := world.events
+	world.events = nil
+
+	return events
+}
+
+// Puts an event in the queue, the oldest events are dropped when the queue has world.MaxEvents events
+//
+// #1 argument event: Event - the event
+func (world *World) pushEvent(event Event) {
+	if world.MaxEvents > 0 && len(world.events) >= world.MaxEvents {
+		world.events = append(world.events[:0], world.events[len(world.events)-world.MaxEvents+1:]...)
+	}
+
+	world.events = append(world.events, event)
+}
@@ -0,0 +1,97 @@
+package rlfp
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Walks the player out of a trigger box around the spawn and takes the types of the trigger events
+//
+// #1 argument world: *World - the world with the trigger box
+//
+// #1 return: []int - the types of the events without the repeated EventTriggerStay events
+func walkOutOfTestTriggerBox(world *World) []int {
+	types := []int{}
+	stepTestWorld(world, 2, [ControlCount]bool{})
+	stepTestWorld(world, 60, getTestInputs(ControlForward))
+
+	for _, event := range world.PollEvents() {
+		if event.Type != EventTriggerStay || len(types) == 0 || types[len(types)-1] != EventTriggerStay {
+			types = append(types, event.Type)
+		}
+	}
+
+	return types
+}
+
+// Entering, staying in and leaving a trigger box sends the events and calls the callbacks
+func TestTriggerEvents(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	id := world.AddTriggerBox(rl.BoundingBox{Min: rl.Vector3{X: -1., Y: 0., Z: -1.}, Max: rl.Vector3{X: 1., Y: 3., Z: 1.}})
+
+	calls := []int{}
+	world.SetTriggerBoxCallbacks(id, TriggerCallbacks{
+		OnEnter: func(world *World, event Event) { calls = append(calls, event.Type) },
+		OnStay:  func(world *World, event Event) {},
+		OnExit:  func(world *World, event Event) { calls = append(calls, event.Type) },
+	})
+
+	types := walkOutOfTestTriggerBox(world)
+	if len(types) != 3 || types[0] != EventTriggerEnter || types[1] != EventTriggerStay || types[2] != EventTriggerExit {
+		t.Errorf("got events %v, want enter, stay and exit", types)
+	}
+	if len(calls) != 2 || calls[0] != EventTriggerEnter || calls[1] != EventTriggerExit {
+		t.Errorf("callbacks got %v, want enter and exit", calls)
+	}
+}
+
+// A trigger box can be removed by its own exit callback
+func TestRemoveTriggerBoxInCallback(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	id := world.AddTriggerBox(rl.BoundingBox{Min: rl.Vector3{X: -1., Y: 0., Z: -1.}, Max: rl.Vector3{X: 1., Y: 3., Z: 1.}})
+	world.AddTriggerBox(rl.BoundingBox{Min: rl.Vector3{X: -1., Y: 0., Z: 5.}, Max: rl.Vector3{X: 1., Y: 3., Z: 6.}})
+
+	exits := 0
+	world.SetTriggerBoxCallbacks(id, TriggerCallbacks{OnExit: func(world *World, event Event) {
+		exits++
+		world.RemoveTriggerBox(event.BoxID)
+	}})
+
+	walkOutOfTestTriggerBox(world)
+	if exits != 1 || len(world.TriggerBoxes) != 1 {
+		t.Errorf("exit callback was called %d times and %d trigger boxes are left, want 1 and 1", exits, len(world.TriggerBoxes))
+	}
+}
+
+// Removing a trigger box with the player inside sends the exit event
+func TestRemoveTriggerBoxWithPlayerInside(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	id := world.AddTriggerBox(rl.BoundingBox{Min: rl.Vector3{X: -1., Y: 0., Z: -1.}, Max: rl.Vector3{X: 1., Y: 3., Z: 1.}})
+	stepTestWorld(world, 2, [ControlCount]bool{})
+	world.PollEvents()
+
+	world.RemoveTriggerBox(id)
+	stepTestWorld(world, 1, [ControlCount]bool{})
+	events := world.PollEvents()
+	if len(events) != 1 || events[0].Type != EventTriggerExit || events[0].BoxID != id {
+		t.Errorf("got events %v after removing the box, want the exit of the box", events)
+	}
+}
+
+// The oldest events are dropped when the queue is full
+func TestMaxEvents(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	world.MaxEvents = 3
+	for i := 0; i < 5; i++ {
+		world.pushEvent(Event{Type: EventTriggerStay, FrameTime: float32(i)})
+	}
+
+	events := world.PollEvents()
+	if len(events) != 3 || events[0].FrameTime != 2. || events[2].FrameTime != 4. {
+		t.Errorf("queue kept %v, want the last 3 events", events)
+	}
+	if len(world.PollEvents()) != 0 {
+		t.Error("queue isn't empty after polling")
+	}
+}
@@ -0,0 +1,188 @@
+package rlfp
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"github.com/chewxy/math32"
+)
+
+// Used for simulating the world in fixed ticks, so the movement doesn't depend on the frame rate
+type FixedTimestep struct {
+	// If the world is updated in fixed ticks instead of once per frame
+	Enabled bool
+	// Number of ticks per second
+	TickRate float32
+	// Maximum number of ticks in one frame, the time over the limit is dropped
+	MaxSubsteps int32
+	// Time that hasn't been simulated yet
+	Accumulator float32
+	// How far the current frame is between the last two ticks (0 - 1), used for interpolation
+	Alpha float32
+	// Player's position before the last tick
+	PreviousPosition rl.Vector3
+	// Player's camera before the last tick
+	PreviousCamera rl.Camera3D
+	// Inputs held in frames without a tick, they are added to the inputs of the next tick, so short key presses aren't lost
+	PendingInputs [ControlCount]bool
+}
+
+// Initializes default values for the fixed timestep, it stays disabled
+func (timestep *FixedTimestep) Init() {
+	timestep.Enabled = false
+	timestep.TickRate = 60.
+	timestep.MaxSubsteps = 8
+	timestep.Accumulator = 0.
+	timestep.Alpha = 0.
+	timestep.PendingInputs = [ControlCount]bool{}
+}
+
+// Advances the world by a rendered frame in fixed ticks of 1 / world.FixedTimestep.TickRate seconds
+// The one frame states (TriggerBox.Triggered, InteractableBox.Interacted) are true after the frame if they were set in any of its ticks,
+// and they are cleared in frames without a tick, so every state is seen in exactly one rendered frame
+// Inputs of frames without a tick are held in world.FixedTimestep.PendingInputs and added to the first tick of the next frame
+//
+// #1 argument frame_time: float32 - time of the frame in seconds, clamped to 0 - 1 second, NaN is 0
+//
+// #2 argument inputs: [ControlCount]bool - state of every control, indexed the same way as world.Player.CurrentInputs
+//
+// #3 argument mouse_delta: rl.Vector2 - mouse movement since the last frame
+func (world *World) StepFixed(frame_time float32, inputs [ControlCount]bool, mouse_delta rl.Vector2) {
+	tick := 1. / world.FixedTimestep.TickRate
+	world.FixedTimestep.Accumulator += clampFrameTime(frame_time)
+
+	// The one frame states of the last frame were already seen
+	world.clearOneFrameStates()
+	world.latched_trigger_boxes = world.latched_trigger_boxes[:0]
+	world.latched_interactable_boxes = world.latched_interactable_boxes[:0]
+
+	substeps := int32(0)
+	for world.FixedTimestep.Accumulator >= tick && substeps < world.FixedTimestep.MaxSubsteps {
+		world.FixedTimestep.PreviousPosition = world.Player.Position
+		world.FixedTimestep.PreviousCamera = world.Player.Camera
+
+		// The mouse movement and the pending inputs are applied only once per frame
+		if substeps == 0 {
+			tick_inputs := inputs
+			for i := range tick_inputs {
+				tick_inputs[i] = tick_inputs[i] || world.FixedTimestep.PendingInputs[i]
+			}
+			world.FixedTimestep.PendingInputs = [ControlCount]bool{}
+
+			world.Step(tick, tick_inputs, mouse_delta)
+		} else {
+			world.Step(tick, inputs, rl.Vector2{X: 0., Y: 0.})
+		}
+		world.latchOneFrameStates()
+
+		world.FixedTimestep.Accumulator -= tick
+		substeps++
+	}
+
+	// Drop the time that couldn't be simulated, so a long hitch doesn't move the player through boxes
+	if world.FixedTimestep.Accumulator >= tick {
+		world.FixedTimestep.Accumulator = math32.Mod(world.FixedTimestep.Accumulator, tick)
+	}
+
+	if substeps == 0 {
+		// Keep the inputs for the next tick
+		for i := range inputs {
+			world.FixedTimestep.PendingInputs[i] = world.FixedTimestep.PendingInputs[i] || inputs[i]
+		}
+
+		// Rotate the camera even when there wasn't a tick this frame
+		world.Player.CurrentInputs = inputs
+		world.Player.Rotate(mouse_delta)
+		world.Player.UpdateCamera()
+	} else {
+		world.restoreOneFrameStates()
+	}
+
+	world.FixedTimestep.Alpha = world.FixedTimestep.Accumulator / tick
+}
+
+// Clears the one frame states of the trigger and interactable boxes, only the active boxes can have them set
+func (world *World) clearOneFrameStates() {
+	for _, i := range world.active_trigger_boxes {
+		world.TriggerBoxes[i].Triggered = false
+	}
+	for _, i := range world.active_interactable_boxes {
+		world.InteractableBoxes[i].Interacted = false
+	}
+}
+
+// Remembers the trigger and interactable boxes whose one frame states were set in the last tick
+func (world *World) latchOneFrameStates() {
+	for _, i := range world.active_trigger_boxes {
+		if world.TriggerBoxes[i].Triggered {
+			world.latched_trigger_boxes = append(world.latched_trigger_boxes, world.TriggerBoxes[i].ID)
+		}
+	}
+	for _, i := range world.active_interactable_boxes {
+		if world.InteractableBoxes[i].Interacted {
+			world.latched_interactable_boxes = append(world.latched_interactable_boxes, world.InteractableBoxes[i].ID)
+		}
+	}
+}
+
+// Sets the one frame states remembered in the ticks of the frame, the boxes are kept active so the states are cleared in the next frame
+func (world *World) restoreOneFrameStates() {
+	for _, id := range world.latched_trigger_boxes {
+		i := world.getBoxIndex(&world.trigger_box_indexes, len(world.TriggerBoxes), id)
+		if i == -1 || world.TriggerBoxes[i].Triggered {
+			continue
+		}
+
+		world.TriggerBoxes[i].Triggered = true
+		if !world.TriggerBoxes[i].Triggering {
+			world.active_trigger_boxes = append(world.active_trigger_boxes, i)
+		}
+	}
+	for _, id := range world.latched_interactable_boxes {
+		i := world.getBoxIndex(&world.interactable_box_indexes, len(world.InteractableBoxes), id)
+		if i == -1 || world.InteractableBoxes[i].Interacted {
+			continue
+		}
+
+		was_active := world.InteractableBoxes[i].isActive()
+		world.InteractableBoxes[i].Interacted = true
+		if !was_active {
+			world.active_interactable_boxes = append(world.active_interactable_boxes, i)
+		}
+	}
+}
+
+// Sets the interpolation to the player's current state, should be called after teleporting the player
+func (world *World) ResetInterpolation() {
+	world.FixedTimestep.Accumulator = 0.
+	world.FixedTimestep.Alpha = 0.
+	world.FixedTimestep.PreviousPosition = world.Player.Position
+	world.FixedTimestep.PreviousCamera = world.Player.Camera
+}
+
+// Gets the player's position interpolated between the last two ticks, used for rendering
+//
+// #1 return: rl.Vector3 - the interpolated position (world.Player.Position if the fixed timestep is disabled)
+func (world *World) GetInterpolatedPosition() rl.Vector3 {
+	if !world.FixedTimestep.Enabled {
+		return world.Player.Position
+	}
+
+	return rl.Vector3Lerp(world.FixedTimestep.PreviousPosition, world.Player.Position, world.FixedTimestep.Alpha)
+}
+
+// Gets the player's camera interpolated between the last two ticks, should be passed to rl.BeginMode3D
+// Only the position is interpolated, the rotation and the FOV are always the current ones
+//
+// #1 return: rl.Camera3D - the interpolated camera (world.Player.Camera if the fixed timestep is disabled)
+func (world *World) GetInterpolatedCamera() rl.Camera3D {
+	camera := world.Player.Camera
+	if !world.FixedTimestep.Enabled {
+		return camera
+	}
+
+	look := rl.Vector3Subtract(camera.Target, camera.Position)
+	camera.Position = rl.Vector3Lerp(world.FixedTimestep.PreviousCamera.Position, world.Player.Camera.Position, world.FixedTimestep.Alpha)
+	camera.Target = rl.Vector3Add(camera.Position, look)
+
+	return camera
+}
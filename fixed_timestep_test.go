@@ -0,0 +1,101 @@
+package rlfp
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"github.com/chewxy/math32"
+)
+
+// Creates a world with the fixed timestep enabled and the player standing on the ground
+//
+// #1 return: *World - the new world
+func newFixedTestWorld() *World {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	world.FixedTimestep.Enabled = true
+	for i := 0; i < 30; i++ {
+		world.Advance(1./60., [ControlCount]bool{}, rl.Vector2{X: 0., Y: 0.})
+	}
+
+	return world
+}
+
+// The player jumps equally high at every frame rate
+func TestStepFixedJumpHeight(t *testing.T) {
+	heights := []float32{}
+	for _, fps := range []float32{30., 60., 144., 240.} {
+		world := newFixedTestWorld()
+
+		max_y := float32(0.)
+		for i := 0; i < int(fps*2.); i++ {
+			world.Advance(1./fps, getTestInputs(ControlJump), rl.Vector2{X: 0., Y: 0.})
+			max_y = math32.Max(max_y, world.Player.BoundingBox.Min.Y)
+		}
+		heights = append(heights, max_y)
+	}
+
+	for i := range heights {
+		if math32.Abs(heights[i]-heights[0]) > .01 {
+			t.Errorf("jump heights %v differ by frame rate", heights)
+			break
+		}
+	}
+}
+
+// The interpolated position is between the positions of the last two ticks
+func TestStepFixedInterpolation(t *testing.T) {
+	world := newFixedTestWorld()
+
+	for i := 0; i < 20; i++ {
+		world.Advance(1./144., getTestInputs(ControlForward), rl.Vector2{X: 0., Y: 0.})
+
+		if world.FixedTimestep.Alpha < 0. || world.FixedTimestep.Alpha >= 1. {
+			t.Fatalf("alpha %g is out of 0 - 1", world.FixedTimestep.Alpha)
+		}
+		position := world.GetInterpolatedPosition()
+		previous, current := world.FixedTimestep.PreviousPosition.X, world.Player.Position.X
+		if position.X < math32.Min(previous, current) || position.X > math32.Max(previous, current) {
+			t.Fatalf("interpolated X %g isn't between %g and %g", position.X, previous, current)
+		}
+	}
+}
+
+// One frame states set in any tick of a frame are seen after the frame and cleared in the next one
+func TestStepFixedKeepsOneFrameStates(t *testing.T) {
+	world := newFixedTestWorld()
+	position := world.Player.Position
+	world.AddTriggerBox(rl.BoundingBox{Min: rl.Vector3{X: position.X - 1., Y: 0., Z: position.Z - 1.}, Max: rl.Vector3{X: position.X + 1., Y: 3., Z: position.Z + 1.}})
+	world.FixedTimestep.Accumulator = 0.
+
+	// The box is entered in the first of the two ticks
+	world.Advance(2./60.+.001, [ControlCount]bool{}, rl.Vector2{X: 0., Y: 0.})
+	if !world.TriggerBoxes[0].Triggered {
+		t.Error("trigger box entered in the first tick isn't triggered after the frame")
+	}
+
+	// Frame without a tick
+	world.Advance(.001, [ControlCount]bool{}, rl.Vector2{X: 0., Y: 0.})
+	if world.TriggerBoxes[0].Triggered || !world.TriggerBoxes[0].Triggering {
+		t.Error("trigger box is still triggered in the next frame")
+	}
+}
+
+// A key pressed only in a frame without a tick is still used by the next tick
+func TestStepFixedKeepsShortPresses(t *testing.T) {
+	world := newFixedTestWorld()
+	world.FixedTimestep.Accumulator = 0.
+
+	world.Advance(.005, getTestInputs(ControlJump), rl.Vector2{X: 0., Y: 0.})
+	if world.Player.YVelocity != 0. || !world.FixedTimestep.PendingInputs[ControlJump] {
+		t.Fatal("jump pressed without a tick isn't pending")
+	}
+
+	world.Advance(1./60., [ControlCount]bool{}, rl.Vector2{X: 0., Y: 0.})
+	if world.Player.YVelocity <= 0. {
+		t.Error("pending jump wasn't used by the next tick")
+	}
+	if world.FixedTimestep.PendingInputs[ControlJump] {
+		t.Error("jump is still pending after the tick")
+	}
+}
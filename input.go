@@ -0,0 +1,289 @@
+package rlfp
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"sync"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Size of an input frame encoded with InputFrame.MarshalBinary
+const InputFrameSize = 10
+
+// Used for reading the state of the player's controls, so bots, replays and remote players can use the same movement code
+type InputSource interface {
+	// Reads the state of every control and the look delta, called once per frame by world.Update
+	//
+	// #1 argument player: *Player - the player that is controlled by the input source
+	//
+	// #2 argument frame_time: float32 - time of the frame in seconds, the same one that is passed to world.Advance
+	//
+	// #1 return: [ControlCount]bool - state of every control, indexed the same way as player.CurrentInputs
+	//
+	// #2 return: rl.Vector2 - how much the player looked around since the last frame (in mouse pixels)
+	Poll(player *Player, frame_time float32) ([ControlCount]bool, rl.Vector2)
+}
+
+// State of the controls in one frame
+type InputFrame struct {
+	// State of every control, indexed the same way as player.CurrentInputs
+	Inputs [ControlCount]bool
+	// How much the player looked around (in mouse pixels)
+	LookDelta rl.Vector2
+}
+
+// Encodes the input frame into InputFrameSize bytes, the inputs are stored as a bit mask followed by the look delta
+//
+// #1 return: []byte - the encoded input frame
+//
+// #2 return: error - always nil
+func (frame InputFrame) MarshalBinary() ([]byte, error) {
+	data := make([]byte, InputFrameSize)
+	binary.LittleEndian.PutUint16(data[0:], encodeInputs(frame.Inputs))
+	binary.LittleEndian.PutUint32(data[2:], math.Float32bits(frame.LookDelta.X))
+	binary.LittleEndian.PutUint32(data[6:], math.Float32bits(frame.LookDelta.Y))
+
+	return data, nil
+}
+
+// Decodes an input frame encoded with InputFrame.MarshalBinary
+//
+// #1 argument data: []byte - the encoded input frame
+//
+// #1 return: error - if the data is not a valid input frame
+func (frame *InputFrame) UnmarshalBinary(data []byte) error {
+	if len(data) != InputFrameSize {
+		return errors.New("rlfp: invalid input frame size")
+	}
+
+	frame.Inputs = decodeInputs(binary.LittleEndian.Uint16(data[0:]))
+	frame.LookDelta.X = math.Float32frombits(binary.LittleEndian.Uint32(data[2:]))
+	frame.LookDelta.Y = math.Float32frombits(binary.LittleEndian.Uint32(data[6:]))
+
+	return nil
+}
+
+// Packs the state of every control into a bit mask
+//
+// #1 argument inputs: [ControlCount]bool - state of every control
+//
+// #1 return: uint16 - the bit mask, bit i is set when inputs[i] is true
+func encodeInputs(inputs [ControlCount]bool) uint16 {
+	mask := uint16(0)
+	for i := range inputs {
+		if inputs[i] {
+			mask |= 1 << i
+		}
+	}
+
+	return mask
+}
+
+// Unpacks a bit mask created by encodeInputs
+//
+// #1 argument mask: uint16 - the bit mask
+//
+// #1 return: [ControlCount]bool - state of every control
+func decodeInputs(mask uint16) [ControlCount]bool {
+	inputs := [ControlCount]bool{}
+	for i := range inputs {
+		inputs[i] = mask&(1<<i) != 0
+	}
+
+	return inputs
+}
+
+// Reads the controls from the keyboard with player.Controls and the look delta from the mouse
+type KeyboardMouseInput struct{}
+
+// Reads the keys in player.Controls and the mouse movement
+//
+// #1 argument player: *Player - the player that is controlled by the input source
+//
+// #2 argument frame_time: float32 - time of the frame in seconds
+//
+// #1 return: [ControlCount]bool - state of every control
+//
+// #2 return: rl.Vector2 - mouse movement since the last frame
+func (input *KeyboardMouseInput) Poll(player *Player, frame_time float32) ([ControlCount]bool, rl.Vector2) {
+	inputs := [ControlCount]bool{}
+	for i := range inputs {
+		inputs[i] = rl.IsKeyDown(player.Controls[i])
+	}
+
+	return inputs, rl.GetMouseDelta()
+}
+
+// Reads the controls from a gamepad, the left stick moves the player and the right stick rotates the camera
+type GamepadInput struct {
+	// Index of the gamepad
+	Gamepad int32
+	// Buttons for every control, movement controls are also activated by the left stick (-1 means unbound)
+	Buttons [ControlCount]int32
+	// How far the stick has to be pushed to register
+	Deadzone float32
+	// How fast the right stick rotates the camera (in mouse pixels per second)
+	LookSpeed float32
+}
+
+// Initializes default values for the gamepad input
+//
+// #1 argument gamepad: int32 - index of the gamepad
+func (input *GamepadInput) Init(gamepad int32) {
+	input.Gamepad = gamepad
+	input.Buttons[ControlForward] = rl.GamepadButtonLeftFaceUp
+	input.Buttons[ControlBackward] = rl.GamepadButtonLeftFaceDown
+	input.Buttons[ControlLeft] = rl.GamepadButtonLeftFaceLeft
+	input.Buttons[ControlRight] = rl.GamepadButtonLeftFaceRight
+	input.Buttons[ControlJump] = rl.GamepadButtonRightFaceDown
+	input.Buttons[ControlCrouch] = rl.GamepadButtonRightFaceRight
+	input.Buttons[ControlSprint] = rl.GamepadButtonLeftThumb
+	input.Buttons[ControlZoom] = rl.GamepadButtonLeftTrigger2
+	input.Buttons[ControlInteract] = rl.GamepadButtonRightFaceLeft
+	input.Buttons[ControlInteract2] = rl.GamepadButtonRightFaceUp
+	input.Buttons[ControlInteract3] = rl.GamepadButtonRightTrigger1
+	input.Deadzone = .25
+	input.LookSpeed = 800.
+}
+
+// Reads the gamepad's buttons and sticks
+//
+// #1 argument player: *Player - the player that is controlled by the input source
+//
+// #2 argument frame_time: float32 - time of the frame in seconds, the right stick rotates by input.LookSpeed per second of it
+//
+// #1 return: [ControlCount]bool - state of every control
+//
+// #2 return: rl.Vector2 - right stick movement converted to mouse pixels
+func (input *GamepadInput) Poll(player *Player, frame_time float32) ([ControlCount]bool, rl.Vector2) {
+	inputs := [ControlCount]bool{}
+	if !rl.IsGamepadAvailable(input.Gamepad) {
+		return inputs, rl.Vector2{X: 0., Y: 0.}
+	}
+
+	for i := range inputs {
+		if input.Buttons[i] != -1 {
+			inputs[i] = rl.IsGamepadButtonDown(input.Gamepad, input.Buttons[i])
+		}
+	}
+
+	// Movement with the left stick
+	move_x := rl.GetGamepadAxisMovement(input.Gamepad, rl.GamepadAxisLeftX)
+	move_y := rl.GetGamepadAxisMovement(input.Gamepad, rl.GamepadAxisLeftY)
+	inputs[ControlForward] = inputs[ControlForward] || move_y < -input.Deadzone
+	inputs[ControlBackward] = inputs[ControlBackward] || move_y > input.Deadzone
+	inputs[ControlLeft] = inputs[ControlLeft] || move_x < -input.Deadzone
+	inputs[ControlRight] = inputs[ControlRight] || move_x > input.Deadzone
+
+	// Looking around with the right stick
+	look_delta := rl.Vector2{
+		X: rl.GetGamepadAxisMovement(input.Gamepad, rl.GamepadAxisRightX),
+		Y: rl.GetGamepadAxisMovement(input.Gamepad, rl.GamepadAxisRightY),
+	}
+	if look_delta.X > -input.Deadzone && look_delta.X < input.Deadzone {
+		look_delta.X = 0.
+	}
+	if look_delta.Y > -input.Deadzone && look_delta.Y < input.Deadzone {
+		look_delta.Y = 0.
+	}
+	look_delta = rl.Vector2Scale(look_delta, input.LookSpeed*frame_time)
+
+	return inputs, look_delta
+}
+
+// Plays back a list of input frames, one frame per poll, used for bots and recorded inputs
+type ScriptedInput struct {
+	// Frames to play back
+	Frames []InputFrame
+	// Index of the next frame
+	Frame int
+	// If the frames should start over after the last one, otherwise nothing is pressed after the last frame
+	Loop bool
+}
+
+// Returns the next frame of the script
+//
+// #1 argument player: *Player - the player that is controlled by the input source
+//
+// #2 argument frame_time: float32 - time of the frame in seconds
+//
+// #1 return: [ControlCount]bool - state of every control in the frame
+//
+// #2 return: rl.Vector2 - look delta of the frame
+func (input *ScriptedInput) Poll(player *Player, frame_time float32) ([ControlCount]bool, rl.Vector2) {
+	if input.Frame >= len(input.Frames) {
+		if !input.Loop || len(input.Frames) == 0 {
+			return [ControlCount]bool{}, rl.Vector2{X: 0., Y: 0.}
+		}
+		input.Frame = 0
+	}
+
+	frame := input.Frames[input.Frame]
+	input.Frame++
+
+	return frame.Inputs, frame.LookDelta
+}
+
+// Returns if every frame of the script has been played
+//
+// #1 return: bool - true if there are no frames left (never true when looping)
+func (input *ScriptedInput) Finished() bool {
+	return !input.Loop && input.Frame >= len(input.Frames)
+}
+
+// Input fed from the network (or any other goroutine), safe for concurrent use
+//
+// The last received controls are held until new ones arrive and look deltas are summed up between polls
+type NetworkInput struct {
+	mutex      sync.Mutex
+	inputs     [ControlCount]bool
+	look_delta rl.Vector2
+}
+
+// Feeds a new input frame
+//
+// #1 argument frame: InputFrame - the received input frame
+func (input *NetworkInput) Push(frame InputFrame) {
+	input.mutex.Lock()
+	defer input.mutex.Unlock()
+
+	input.inputs = frame.Inputs
+	input.look_delta = rl.Vector2Add(input.look_delta, frame.LookDelta)
+}
+
+// Feeds a new input frame encoded with InputFrame.MarshalBinary
+//
+// #1 argument packet: []byte - the received packet
+//
+// #1 return: error - if the packet is not a valid input frame
+func (input *NetworkInput) Receive(packet []byte) error {
+	frame := InputFrame{}
+	if err := frame.UnmarshalBinary(packet); err != nil {
+		return err
+	}
+
+	input.Push(frame)
+
+	return nil
+}
+
+// Returns the last received controls and the look delta received since the last poll
+//
+// #1 argument player: *Player - the player that is controlled by the input source
+//
+// #2 argument frame_time: float32 - time of the frame in seconds
+//
+// #1 return: [ControlCount]bool - the last received state of every control
+//
+// #2 return: rl.Vector2 - sum of the look deltas received since the last poll
+func (input *NetworkInput) Poll(player *Player, frame_time float32) ([ControlCount]bool, rl.Vector2) {
+	input.mutex.Lock()
+	defer input.mutex.Unlock()
+
+	look_delta := input.look_delta
+	input.look_delta = rl.Vector2{X: 0., Y: 0.}
+
+	return input.inputs, look_delta
+}
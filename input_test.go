@@ -0,0 +1,95 @@
+package rlfp
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// An input frame is the same after encoding and decoding it
+func TestInputFrameBinary(t *testing.T) {
+	frame := InputFrame{Inputs: getTestInputs(ControlForward, ControlJump, ControlInteract), LookDelta: rl.Vector2{X: 1.5, Y: -2.25}}
+
+	data, err := frame.MarshalBinary()
+	if err != nil || len(data) != InputFrameSize {
+		t.Fatalf("encoded %d bytes with error %v, want %d bytes", len(data), err, InputFrameSize)
+	}
+
+	decoded := InputFrame{}
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != frame {
+		t.Errorf("decoded %+v, want %+v", decoded, frame)
+	}
+
+	if err := decoded.UnmarshalBinary(data[1:]); err == nil {
+		t.Error("decoding a short frame didn't fail")
+	}
+}
+
+// A scripted input plays its frames in order and stops or loops after the last one
+func TestScriptedInput(t *testing.T) {
+	frames := []InputFrame{
+		{Inputs: getTestInputs(ControlForward), LookDelta: rl.Vector2{X: 1., Y: 0.}},
+		{Inputs: getTestInputs(ControlJump), LookDelta: rl.Vector2{X: 0., Y: 2.}},
+	}
+
+	input := &ScriptedInput{Frames: frames}
+	for i := range frames {
+		inputs, look_delta := input.Poll(nil, 1./60.)
+		if inputs != frames[i].Inputs || look_delta != frames[i].LookDelta {
+			t.Errorf("frame %d is %v %v, want %v %v", i, inputs, look_delta, frames[i].Inputs, frames[i].LookDelta)
+		}
+	}
+	if !input.Finished() {
+		t.Error("script isn't finished after the last frame")
+	}
+	if inputs, _ := input.Poll(nil, 1./60.); inputs != ([ControlCount]bool{}) {
+		t.Errorf("finished script pressed %v", inputs)
+	}
+
+	looping := &ScriptedInput{Frames: frames, Loop: true}
+	for i := 0; i < 3; i++ {
+		looping.Poll(nil, 1./60.)
+	}
+	if inputs, _ := looping.Poll(nil, 1./60.); inputs != frames[1].Inputs || looping.Finished() {
+		t.Errorf("looping script gave %v after 3 frames, want %v", inputs, frames[1].Inputs)
+	}
+}
+
+// A network input keeps the last controls and sums the look deltas between polls
+func TestNetworkInput(t *testing.T) {
+	input := &NetworkInput{}
+	input.Push(InputFrame{Inputs: getTestInputs(ControlForward), LookDelta: rl.Vector2{X: 1., Y: 1.}})
+
+	packet, _ := InputFrame{Inputs: getTestInputs(ControlSprint), LookDelta: rl.Vector2{X: 2., Y: -3.}}.MarshalBinary()
+	if err := input.Receive(packet); err != nil {
+		t.Fatal(err)
+	}
+
+	inputs, look_delta := input.Poll(nil, 1./60.)
+	if inputs != getTestInputs(ControlSprint) || look_delta != (rl.Vector2{X: 3., Y: -2.}) {
+		t.Errorf("polled %v %v, want the last controls and the summed look delta", inputs, look_delta)
+	}
+	if inputs, look_delta = input.Poll(nil, 1./60.); inputs != getTestInputs(ControlSprint) || look_delta != (rl.Vector2{X: 0., Y: 0.}) {
+		t.Errorf("second poll gave %v %v, want the held controls without look delta", inputs, look_delta)
+	}
+}
+
+// The player reads the keyboard and the mouse when it has no input source
+func TestPlayerInputFallsBackToKeyboardMouse(t *testing.T) {
+	player := Player{}
+	player.Init()
+	player.Input = nil
+
+	if _, ok := player.getInput().(*KeyboardMouseInput); !ok {
+		t.Errorf("player without input reads from %T, want *KeyboardMouseInput", player.getInput())
+	}
+
+	scripted := &ScriptedInput{}
+	player.Input = scripted
+	if player.getInput() != scripted {
+		t.Error("player doesn't read from its input source")
+	}
+}
@@ -6,53 +6,368 @@ import (
 
 // Used for interacting with the world with a key press
 type InteractableBox struct {
+	// Stable identifier of the box
+	ID BoxID
 	// The bounding box of the interactable object
 	BoundingBox rl.BoundingBox
 	// The bounding box of the interactable object with a little extra space, used for drawing, when the player can interact with it
 	BoundingBoxOver rl.BoundingBox
-	// If the player has interacted with the object (one frame)
+	// Rotated shape of the box, used instead of BoundingBox when IsOriented is true, BoundingBox is then the axis-aligned box around it
+	Oriented OrientedBox
+	// If the box has a rotated shape
+	IsOriented bool
+	// If the player has interacted with the object (one frame), for boxes with a hold duration in the frame the hold is completed
 	Interacted bool
 	// If the player is interacting with the object (holding the key)
 	Interacting bool
 	// The ray collision of the mouse ray and the interactable object
 	RayCollision rl.RayCollision
+	// If the box is updated even when it's farther than world.CalculationDistance
+	AlwaysActive bool
+	// If the player can't interact with the box
+	Disabled bool
+	// How long the interact key has to be held to complete the interaction in seconds, 0 for instant interaction
+	HoldDuration float32
+	// How long the interact key has been held while looking at the box in seconds
+	HoldTime float32
+	// Name of the object shown to the player
+	Name string
+	// Text shown to the player when looking at the box, e.g. "Press E to open"
+	Prompt string
+	// Verbs of the box bound to the interact controls, when it's empty ControlInteract fires a verb without a name
+	Verbs []InteractVerb
+	// Index of the verb in Verbs the player is using, valid while Interacting
+	ActiveVerb int
+	// How long the box can't be used after an interaction in seconds
+	Cooldown float32
+	// Time left until the box can be used again in seconds
+	CooldownTime float32
+}
+
+// Action the player can do with an interactable box, e.g. E to use or F to inspect
+type InteractVerb struct {
+	// Name reported in the interaction events
+	Name string
+	// Control that fires the verb (ControlInteract, ControlInteract2, ControlInteract3)
+	Control int
+	// Text shown to the player for the verb, e.g. "Press F to inspect"
+	Prompt string
+}
+
+// Verbs of interactable boxes without their own verbs
+var defaultInteractVerbs = []InteractVerb{{Name: "", Control: ControlInteract, Prompt: ""}}
+
+// Controls that can fire verbs of interactable boxes
+var interactControls = [...]int{ControlInteract, ControlInteract2, ControlInteract3}
+
+// Interactable box the player is looking at
+type InteractableFocus struct {
+	// ID of the box
+	ID BoxID
+	// Where the mouse ray hits the box
+	Point rl.Vector3
+	// Normal of the hit side of the box
+	Normal rl.Vector3
+	// Distance from the camera to the point
+	Distance float32
 }
 
 // Creates a new interactable box and puts it in world.InteractableBoxes array
 //
 // #1 argument box: rl.BoundingBox - the bounding box of the interactable object (position)
-func (world *World) AddInteractableBox(box rl.BoundingBox) {
+//
+// #1 return: BoxID - stable ID of the new box
+func (world *World) AddInteractableBox(box rl.BoundingBox) BoxID {
+	id := world.newBoxID()
+
 	world.InteractableBoxes = append(world.InteractableBoxes, InteractableBox{
-		box,
-		rl.BoundingBox{
-			Min: rl.Vector3{
-				X: box.Min.X - .02,
-				Y: box.Min.Y - .02,
-				Z: box.Min.Z - .02,
-			},
-			Max: rl.Vector3{
-				X: box.Max.X + .02,
-				Y: box.Max.Y + .02,
-				Z: box.Max.Z + .02,
-			},
-		},
-		false,
-		false,
-		rl.RayCollision{
+		ID:              id,
+		BoundingBox:     box,
+		BoundingBoxOver: getBoundingBoxOver(box),
+		RayCollision: rl.RayCollision{
 			Hit:      false,
 			Distance: 0.,
 			Point:    rl.Vector3{X: 0., Y: 0., Z: 0.},
 			Normal:   rl.Vector3{X: 0., Y: 0., Z: 0.},
 		},
 	})
+	if world.interactable_box_grid.isInitialized() {
+		world.interactable_box_grid.insert(len(world.InteractableBoxes)-1, box)
+	}
+	if world.interactable_box_indexes != nil {
+		world.interactable_box_indexes[id] = len(world.InteractableBoxes) - 1
+	}
+
+	return id
+}
+
+// Gets the bounding box of an interactable object with a little extra space, used for drawing
+//
+// #1 argument box: rl.BoundingBox - the bounding box of the interactable object
+//
+// #1 return: rl.BoundingBox - the bigger box
+func getBoundingBoxOver(box rl.BoundingBox) rl.BoundingBox {
+	return rl.BoundingBox{
+		Min: rl.Vector3{
+			X: box.Min.X - .02,
+			Y: box.Min.Y - .02,
+			Z: box.Min.Z - .02,
+		},
+		Max: rl.Vector3{
+			X: box.Max.X + .02,
+			Y: box.Max.Y + .02,
+			Z: box.Max.Z + .02,
+		},
+	}
+}
+
+// Removes an interactable box from the world, the last box takes its place in world.InteractableBoxes
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #1 return: bool - false if there is no interactable box with the ID
+func (world *World) RemoveInteractableBox(id BoxID) bool {
+	i := world.getBoxIndex(&world.interactable_box_indexes, len(world.InteractableBoxes), id)
+	if i == -1 {
+		return false
+	}
+	last := len(world.InteractableBoxes) - 1
+	removed := world.InteractableBoxes[i]
+
+	world.interactable_box_grid.remove(i)
+	if i != last {
+		world.interactable_box_grid.remove(last)
+		world.interactable_box_grid.insert(i, world.InteractableBoxes[last].BoundingBox)
+		world.InteractableBoxes[i] = world.InteractableBoxes[last]
+		world.interactable_box_indexes[world.InteractableBoxes[i].ID] = i
+	}
+	world.InteractableBoxes = world.InteractableBoxes[:last]
+	delete(world.interactable_box_indexes, id)
+	world.active_interactable_boxes = fixKeysAfterRemove(world.active_interactable_boxes, i, last)
+	if world.focused_interactable.ID == id {
+		world.focused_interactable = InteractableFocus{}
+	}
+	if removed.isHolding() {
+		world.addInteractEvent(EventInteractCancelled, &removed)
+	}
+
+	return true
+}
+
+// Moves or resizes an interactable box, an oriented box becomes axis-aligned
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #2 argument box: rl.BoundingBox - the new box
+//
+// #1 return: bool - false if there is no interactable box with the ID
+func (world *World) SetInteractableBoxBounds(id BoxID, box rl.BoundingBox) bool {
+	i := world.getBoxIndex(&world.interactable_box_indexes, len(world.InteractableBoxes), id)
+	if i == -1 {
+		return false
+	}
+
+	world.interactable_box_grid.remove(i)
+	world.InteractableBoxes[i].BoundingBox = box
+	world.InteractableBoxes[i].BoundingBoxOver = getBoundingBoxOver(box)
+	world.InteractableBoxes[i].IsOriented = false
+	world.interactable_box_grid.insert(i, box)
+
+	return true
+}
+
+// Gets an interactable box by its ID
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #1 return: InteractableBox - copy of the box
+//
+// #2 return: bool - false if there is no interactable box with the ID
+func (world *World) GetInteractableBox(id BoxID) (InteractableBox, bool) {
+	i := world.getBoxIndex(&world.interactable_box_indexes, len(world.InteractableBoxes), id)
+	if i == -1 {
+		return InteractableBox{}, false
+	}
+
+	return world.InteractableBoxes[i], true
+}
+
+// Enables or disables an interactable box, disabling resets its states
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #2 argument enabled: bool - if the player can interact with the box
+//
+// #1 return: bool - false if there is no interactable box with the ID
+func (world *World) SetInteractableBoxEnabled(id BoxID, enabled bool) bool {
+	i := world.getBoxIndex(&world.interactable_box_indexes, len(world.InteractableBoxes), id)
+	if i == -1 {
+		return false
+	}
+
+	world.InteractableBoxes[i].Disabled = !enabled
+	if !enabled {
+		if world.InteractableBoxes[i].isHolding() {
+			world.addInteractEvent(EventInteractCancelled, &world.InteractableBoxes[i])
+		}
+		world.InteractableBoxes[i].HoldTime = 0.
+		world.InteractableBoxes[i].Interacted = false
+		world.InteractableBoxes[i].Interacting = false
+		world.InteractableBoxes[i].RayCollision = rl.RayCollision{}
+		if world.focused_interactable.ID == id {
+			world.focused_interactable = InteractableFocus{}
+		}
+	}
+
+	return true
+}
+
+// Sets how long the interact key has to be held to complete the interaction with an interactable box
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #2 argument duration: float32 - the duration in seconds, 0 for instant interaction
+//
+// #1 return: bool - false if there is no interactable box with the ID
+func (world *World) SetInteractableBoxHoldDuration(id BoxID, duration float32) bool {
+	i := world.getBoxIndex(&world.interactable_box_indexes, len(world.InteractableBoxes), id)
+	if i == -1 {
+		return false
+	}
+
+	world.InteractableBoxes[i].HoldDuration = duration
+	world.InteractableBoxes[i].HoldTime = 0.
+
+	return true
+}
+
+// Sets the name and the prompt shown to the player for an interactable box
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #2 argument name: string - name of the object
+//
+// #3 argument prompt: string - text shown when looking at the box
+//
+// #1 return: bool - false if there is no interactable box with the ID
+func (world *World) SetInteractableBoxPrompt(id BoxID, name string, prompt string) bool {
+	i := world.getBoxIndex(&world.interactable_box_indexes, len(world.InteractableBoxes), id)
+	if i == -1 {
+		return false
+	}
+
+	world.InteractableBoxes[i].Name = name
+	world.InteractableBoxes[i].Prompt = prompt
+
+	return true
+}
+
+// Sets the verbs of an interactable box, the current interaction with the box is stopped
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #2 argument verbs: []InteractVerb - the verbs, nil for a verb without a name bound to ControlInteract
+//
+// #1 return: bool - false if there is no interactable box with the ID
+func (world *World) SetInteractableBoxVerbs(id BoxID, verbs []InteractVerb) bool {
+	i := world.getBoxIndex(&world.interactable_box_indexes, len(world.InteractableBoxes), id)
+	if i == -1 {
+		return false
+	}
+
+	world.InteractableBoxes[i].Verbs = append([]InteractVerb{}, verbs...)
+	world.InteractableBoxes[i].ActiveVerb = 0
+	world.InteractableBoxes[i].Interacted = false
+	world.InteractableBoxes[i].Interacting = false
+	world.InteractableBoxes[i].HoldTime = 0.
+
+	return true
+}
+
+// Sets how long an interactable box can't be used after an interaction
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #2 argument cooldown: float32 - the cooldown in seconds, 0 for no cooldown
+//
+// #1 return: bool - false if there is no interactable box with the ID
+func (world *World) SetInteractableBoxCooldown(id BoxID, cooldown float32) bool {
+	i := world.getBoxIndex(&world.interactable_box_indexes, len(world.InteractableBoxes), id)
+	if i == -1 {
+		return false
+	}
+
+	world.InteractableBoxes[i].Cooldown = cooldown
+	if world.InteractableBoxes[i].CooldownTime > cooldown {
+		world.InteractableBoxes[i].CooldownTime = cooldown
+	}
+
+	return true
+}
+
+// Gets the verbs of the box
+//
+// #1 return: []InteractVerb - box.Verbs, or a verb without a name bound to ControlInteract if the box has no verbs
+func (box *InteractableBox) GetVerbs() []InteractVerb {
+	if len(box.Verbs) == 0 {
+		return defaultInteractVerbs
+	}
+
+	return box.Verbs
+}
+
+// Gets the verb the player is using
+//
+// #1 return: InteractVerb - the verb, valid while box.Interacting
+func (box *InteractableBox) GetActiveVerb() InteractVerb {
+	verbs := box.GetVerbs()
+	if box.ActiveVerb < 0 || box.ActiveVerb >= len(verbs) {
+		return verbs[0]
+	}
+
+	return verbs[box.ActiveVerb]
+}
+
+// Gets how much of the hold duration has passed
+//
+// #1 return: float32 - progress of the interaction (0 - 1), 0 for boxes without a hold duration
+func (box *InteractableBox) GetHoldProgress() float32 {
+	if box.HoldDuration <= 0. {
+		return 0.
+	}
+
+	return box.HoldTime / box.HoldDuration
+}
+
+// Checks if the player is holding the interact key on the box and the interaction isn't completed yet
+//
+// #1 return: bool - true if the interaction would be cancelled by releasing the key
+func (box *InteractableBox) isHolding() bool {
+	return box.HoldDuration > 0. && box.HoldTime > 0. && box.HoldTime < box.HoldDuration
+}
+
+// Checks if the interactable box has to be updated even when it's out of the player's reach
+//
+// #1 return: bool - true if the box is hit by the mouse ray, interacted with or cooling down
+func (box *InteractableBox) isActive() bool {
+	return box.RayCollision.Hit || box.Interacted || box.Interacting || box.CooldownTime > 0.
 }
 
 // Updates the interactable boxes
 //
-// #1 argument window_width: int32 - the width of the window
+// Deprecated: world.Step updates the interactable boxes, use world.StepInteractableBoxes to update them with a known mouse ray
+//
+// #1 argument window_width: int32 - the width of the window (unused, the ray is cast from the center of the camera)
 //
-// #2 argument window_height: int32 - the height of the window
+// #2 argument window_height: int32 - the height of the window (unused, the ray is cast from the center of the camera)
 func (world *World) UpdateInteractableBoxes(window_width int32, window_height int32) {
+	world.StepInteractableBoxes(world.Player.GetLookRay())
+}
+
+// Updates the interactable boxes by the ray the player looks along, called by world.Step
+//
+// #1 argument mouse_ray: rl.Ray - the ray going from the camera through the center of the screen
+func (world *World) StepInteractableBoxes(mouse_ray rl.Ray) {
 	// If the player has already interacted with an object, reset the interacted state of all interactable boxes
 	if !world.AlreadySetInteractStates && world.Player.AlreadyInteracted {
 		for i := range world.InteractableBoxes {
@@ -62,25 +377,64 @@ func (world *World) UpdateInteractableBoxes(window_width int32, window_height in
 		world.AlreadySetInteractStates = true
 	}
 
-	mouse_ray := rl.GetScreenToWorldRay(
-		rl.Vector2{
-			X: float32(window_width) / 2,
-			Y: float32(window_height) / 2,
-		},
-		world.Player.Camera,
-	)
+	// Area which the mouse ray goes through in the player's reach
+	ray_end := rl.Vector3Add(mouse_ray.Position, rl.Vector3Scale(mouse_ray.Direction, world.Player.InteractRange))
+	ray_area := rl.BoundingBox{
+		Min: rl.Vector3Min(mouse_ray.Position, ray_end),
+		Max: rl.Vector3Max(mouse_ray.Position, ray_end),
+	}
+	world.interact_reach = world.getInteractReach(mouse_ray, ray_area)
+	candidates := world.queryInteractableBoxes(ray_area)
+
+	// Find the closest interactable box hit by the mouse ray in the player's reach, nothing is focused without interactions
+	world.focused_interactable = InteractableFocus{}
+	has_interactions := world.Player.getMovement().HasInteractions()
+	for _, i := range candidates {
+		if world.InteractableBoxes[i].Disabled ||
+			!world.isInCalculationDistance(world.InteractableBoxes[i].BoundingBox, world.InteractableBoxes[i].AlwaysActive) {
+
+			continue
+		}
+
+		collision := world.getInteractableBoxRayCollision(i, mouse_ray)
+		world.InteractableBoxes[i].RayCollision = collision
+
+		if has_interactions && collision.Hit && collision.Distance <= world.interact_reach &&
+			(world.focused_interactable.ID == 0 || collision.Distance < world.focused_interactable.Distance) {
+
+			world.focused_interactable = InteractableFocus{
+				ID:       world.InteractableBoxes[i].ID,
+				Point:    collision.Point,
+				Normal:   collision.Normal,
+				Distance: collision.Distance,
+			}
+		}
+	}
 
 	// Update the individual interactable boxes
-	for i := range world.InteractableBoxes {
-		if getDistance(world.Player.Position.X, world.Player.Position.Z,
-			world.InteractableBoxes[i].BoundingBox.Min.X, world.InteractableBoxes[i].BoundingBox.Min.Z) <= world.CalculationDistance {
+	world.active_interactable_boxes = world.active_interactable_boxes[:0]
+	for _, i := range candidates {
+		if world.InteractableBoxes[i].CooldownTime > 0. {
+			world.InteractableBoxes[i].CooldownTime -= world.FrameTime
+			if world.InteractableBoxes[i].CooldownTime < 0. {
+				world.InteractableBoxes[i].CooldownTime = 0.
+			}
+		}
+
+		if !world.InteractableBoxes[i].Disabled &&
+			world.isInCalculationDistance(world.InteractableBoxes[i].BoundingBox, world.InteractableBoxes[i].AlwaysActive) {
+
+			world.updateInteractableBoxState(i)
+		}
 
-			world.UpdateInteractableBox(i, &mouse_ray)
+		// Remember the boxes that are hit or interacted with, so they are updated when the player looks away
+		if world.InteractableBoxes[i].isActive() {
+			world.active_interactable_boxes = append(world.active_interactable_boxes, i)
 		}
 	}
 
 	// If the player is not interacting with any object, reset the interacted state of all interactable boxes
-	if world.Player.CurrentInputs[ControlInteract] {
+	if world.Player.isInteractPressed() {
 		world.Player.AlreadyInteracted = true
 	} else {
 		world.Player.AlreadyInteracted = false
@@ -88,24 +442,90 @@ func (world *World) UpdateInteractableBoxes(window_width int32, window_height in
 	}
 }
 
-// Updates an interactable box
+// Gets how far the player can interact along the mouse ray, solid boxes and colliders block interaction unless they are see-through
+//
+// #1 argument mouse_ray: rl.Ray - the ray going from the camera through the center of the screen
+//
+// #2 argument ray_area: rl.BoundingBox - area which the mouse ray goes through in the player's reach
+//
+// #1 return: float32 - distance to the closest blocking box, world.Player.InteractRange if nothing is in the way
+func (world *World) getInteractReach(mouse_ray rl.Ray, ray_area rl.BoundingBox) float32 {
+	reach := world.Player.InteractRange
+
+	for _, i := range world.queryBoundingBoxes(ray_area) {
+		if world.BoundingBoxes[i].SeeThrough || !world.isBoundingBoxActive(i) {
+			continue
+		}
+
+		// Interactable boxes on the surface of a solid box have the same distance, so they are still in reach
+		collision := world.getRayCollisionBoundingBox(mouse_ray, i)
+		if collision.Hit && collision.Distance >= 0. && collision.Distance < reach {
+			reach = collision.Distance
+		}
+	}
+	for _, i := range world.queryColliders(ray_area) {
+		if world.Colliders[i].SeeThrough || !world.isColliderActive(i) {
+			continue
+		}
+
+		collision := world.Colliders[i].Collider.GetRayCollision(mouse_ray)
+		if collision.Hit && collision.Distance >= 0. && collision.Distance < reach {
+			reach = collision.Distance
+		}
+	}
+
+	return reach
+}
+
+// Updates an interactable box, only the box focused in the last world.UpdateInteractableBoxes can start being interacted with
 //
 // #1 argument i: int - the index of the interactable box to update
 //
 // #2 argument mouse_ray: *rl.Ray - the current mouse ray
 func (world *World) UpdateInteractableBox(i int, mouse_ray *rl.Ray) {
-	world.InteractableBoxes[i].RayCollision = rl.GetRayCollisionBox(*mouse_ray, world.InteractableBoxes[i].BoundingBox)
+	world.InteractableBoxes[i].RayCollision = world.getInteractableBoxRayCollision(i, *mouse_ray)
+	world.updateInteractableBoxState(i)
+}
 
-	// Setting the states of the interactable box
-	if world.Player.CurrentInputs[ControlInteract] && (!world.Player.AlreadyInteracted ||
-		world.InteractableBoxes[i].RayCollision.Distance > world.Player.InteractRange) {
+// Gets where a ray hits an interactable box
+//
+// #1 argument i: int - the index of the interactable box
+//
+// #2 argument ray: rl.Ray - the ray
+//
+// #1 return: rl.RayCollision - the collision with the box
+func (world *World) getInteractableBoxRayCollision(i int, ray rl.Ray) rl.RayCollision {
+	if world.InteractableBoxes[i].IsOriented {
+		return GetRayCollisionOrientedBox(ray, world.InteractableBoxes[i].Oriented)
+	}
+
+	return rl.GetRayCollisionBox(ray, world.InteractableBoxes[i].BoundingBox)
+}
 
-		if (world.InteractableBoxes[i].RayCollision.Hit && world.InteractableBoxes[i].RayCollision.Distance <= world.Player.InteractRange) ||
-			world.InteractableBoxes[i].Interacting {
+// Updates the states of an interactable box, only the focused box can start being interacted with
+//
+// #1 argument i: int - the index of the interactable box to update
+func (world *World) updateInteractableBoxState(i int) {
+	is_focused := world.focused_interactable.ID != 0 && world.InteractableBoxes[i].ID == world.focused_interactable.ID
+	verb := world.getPressedVerb(i)
+
+	// Setting the states of the interactable box
+	if verb != -1 && (!world.Player.AlreadyInteracted || !is_focused) {
+		if world.InteractableBoxes[i].Interacting ||
+			(is_focused && world.InteractableBoxes[i].CooldownTime <= 0.) {
 
 			world.Player.AlreadyInteracted = true
 			if !world.InteractableBoxes[i].Interacting {
-				world.InteractableBoxes[i].Interacted = true
+				world.InteractableBoxes[i].ActiveVerb = verb
+				if world.InteractableBoxes[i].HoldDuration <= 0. {
+					world.InteractableBoxes[i].Interacted = true
+					world.addInteractEvent(EventInteract, &world.InteractableBoxes[i])
+					world.InteractableBoxes[i].CooldownTime = world.InteractableBoxes[i].Cooldown
+				} else {
+					// Boxes with a hold duration are interacted with when the hold is completed
+					world.InteractableBoxes[i].Interacted = false
+					world.addInteractEvent(EventInteractStarted, &world.InteractableBoxes[i])
+				}
 			} else {
 				world.InteractableBoxes[i].Interacted = false
 			}
@@ -114,14 +534,120 @@ func (world *World) UpdateInteractableBox(i int, mouse_ray *rl.Ray) {
 			world.InteractableBoxes[i].Interacting = false
 			world.InteractableBoxes[i].Interacted = false
 		}
-	} else if !world.Player.CurrentInputs[ControlInteract] {
+	} else if verb == -1 {
 		world.InteractableBoxes[i].Interacting = false
 		world.InteractableBoxes[i].Interacted = false
-		world.Player.AlreadyInteracted = false
+		// Controls of other verbs can still be held
+		if !world.Player.isInteractPressed() {
+			world.Player.AlreadyInteracted = false
+		}
+	}
+
+	if world.InteractableBoxes[i].HoldDuration > 0. {
+		world.updateInteractableBoxHold(i, is_focused)
+	}
+}
+
+// Updates the hold time of an interactable box, the interaction is cancelled when the player looks away, leaves the reach or releases the key
+//
+// #1 argument i: int - the index of the interactable box
+//
+// #2 argument is_focused: bool - if the player is looking at the box in reach
+func (world *World) updateInteractableBoxHold(i int, is_focused bool) {
+	box := &world.InteractableBoxes[i]
+
+	// Released the key or looked away before the interaction was completed
+	if box.isHolding() && (!box.Interacting || !is_focused) {
+		box.Interacting = false
+		box.Interacted = false
+		box.HoldTime = 0.
+		world.addInteractEvent(EventInteractCancelled, box)
+		return
+	}
+
+	if !box.Interacting {
+		box.HoldTime = 0.
+		return
+	}
+
+	// Interacted is only set in the frame the hold is completed
+	box.Interacted = false
+	if box.HoldTime < box.HoldDuration {
+		box.HoldTime += world.FrameTime
+		if box.HoldTime >= box.HoldDuration {
+			box.HoldTime = box.HoldDuration
+			box.CooldownTime = box.Cooldown
+			box.Interacted = true
+			world.addInteractEvent(EventInteract, box)
+			world.addInteractEvent(EventInteractCompleted, box)
+		}
+	}
+}
+
+// Gets the verb of an interactable box whose control is down, the verb in use is kept until its control is released
+//
+// #1 argument i: int - the index of the interactable box
+//
+// #1 return: int - index of the verb in box.GetVerbs(), -1 if no verb's control is down
+func (world *World) getPressedVerb(i int) int {
+	verbs := world.InteractableBoxes[i].GetVerbs()
+
+	if world.InteractableBoxes[i].Interacting {
+		verb := world.InteractableBoxes[i].ActiveVerb
+		if verb >= 0 && verb < len(verbs) && world.Player.isControlDown(verbs[verb].Control) {
+			return verb
+		}
+
+		return -1
+	}
+
+	for verb := range verbs {
+		if world.Player.isControlDown(verbs[verb].Control) {
+			return verb
+		}
 	}
+
+	return -1
+}
+
+// Checks if a control is down
+//
+// #1 argument control: int - index of the control
+//
+// #1 return: bool - false also for invalid controls
+func (player *Player) isControlDown(control int) bool {
+	return control >= 0 && control < ControlCount && player.CurrentInputs[control]
+}
+
+// Checks if any of the controls that can fire verbs of interactable boxes is down
+//
+// #1 return: bool - true if ControlInteract, ControlInteract2 or ControlInteract3 is down
+func (player *Player) isInteractPressed() bool {
+	for _, control := range interactControls {
+		if player.CurrentInputs[control] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Puts an interaction event caused by the player in the queue
+//
+// #1 argument event_type: int - type of the event (EventInteract, EventInteractStarted, EventInteractCompleted, EventInteractCancelled)
+//
+// #2 argument box: *InteractableBox - the interactable box, its active verb is reported
+func (world *World) addInteractEvent(event_type int, box *InteractableBox) {
+	world.pushEvent(Event{
+		Type:      event_type,
+		BoxID:     box.ID,
+		Entity:    Entity{Kind: EntityPlayer, ID: 0},
+		FrameTime: world.FrameTime,
+		Verb:      box.GetActiveVerb().Name,
+	})
 }
 
-// Draws boundingBoxOver of an interactable object, if the player can interact with it
+// Draws boundingBoxOver of the focused interactable object, if the player can interact with it
 func (world *World) DrawBoundingBoxOver() {
 	// If the player is interacting with an object, don't draw the bounding box
 	for i := range world.InteractableBoxes {
@@ -130,11 +656,23 @@ func (world *World) DrawBoundingBoxOver() {
 		}
 	}
 
-	// If the player can interact with an object, draw the bounding box
-	for i := range world.InteractableBoxes {
-		if world.InteractableBoxes[i].RayCollision.Hit && world.InteractableBoxes[i].RayCollision.Distance <= world.Player.InteractRange {
-			rl.DrawBoundingBox(world.InteractableBoxes[i].BoundingBoxOver, rl.White)
-			return
-		}
+	if world.focused_interactable.ID == 0 {
+		return
 	}
+
+	i := world.getBoxIndex(&world.interactable_box_indexes, len(world.InteractableBoxes), world.focused_interactable.ID)
+	if i != -1 && world.InteractableBoxes[i].IsOriented {
+		DrawOrientedBox(world.InteractableBoxes[i].Oriented.getBoxOver(), rl.White)
+	} else if i != -1 {
+		rl.DrawBoundingBox(world.InteractableBoxes[i].BoundingBoxOver, rl.White)
+	}
+}
+
+// Gets the interactable box the player is looking at, it's the closest one hit by the mouse ray in the player's reach
+//
+// #1 return: InteractableFocus - the focused box with the hit point and normal
+//
+// #2 return: bool - false if the player isn't looking at any interactable box
+func (world *World) FocusedInteractable() (InteractableFocus, bool) {
+	return world.focused_interactable, world.focused_interactable.ID != 0
 }
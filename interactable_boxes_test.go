@@ -0,0 +1,213 @@
+package rlfp
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Creates a world with the player standing still on the ground
+//
+// #1 return: *World - the new world
+func newInteractTestWorld() *World {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	stepTestWorld(world, 30, [ControlCount]bool{})
+
+	return world
+}
+
+// Creates a cube on the player's look ray
+//
+// #1 argument world: *World - the world with the player
+//
+// #2 argument distance: float32 - distance of the center of the cube from the camera
+//
+// #3 argument half_size: float32 - half of the size of the cube
+//
+// #1 return: rl.BoundingBox - the cube
+func getLookTestBox(world *World, distance float32, half_size float32) rl.BoundingBox {
+	ray := world.Player.GetLookRay()
+	center := rl.Vector3Add(ray.Position, rl.Vector3Scale(ray.Direction, distance))
+	half := rl.Vector3{X: half_size, Y: half_size, Z: half_size}
+
+	return rl.BoundingBox{Min: rl.Vector3Subtract(center, half), Max: rl.Vector3Add(center, half)}
+}
+
+// A solid box between the player and an interactable box blocks the interaction unless it's see-through
+func TestInteractOcclusion(t *testing.T) {
+	world := newInteractTestWorld()
+	world.AddInteractableBox(getLookTestBox(world, 2.5, .2))
+	wall := world.AddBoundingBox(getLookTestBox(world, 1.5, .1))
+
+	stepTestWorld(world, 1, getTestInputs(ControlInteract))
+	if world.InteractableBoxes[0].Interacting || world.InteractableBoxes[0].Interacted {
+		t.Error("player interacted through a solid box")
+	}
+
+	world.SetBoundingBoxSeeThrough(wall, true)
+	stepTestWorld(world, 1, [ControlCount]bool{})
+	stepTestWorld(world, 1, getTestInputs(ControlInteract))
+	if !world.InteractableBoxes[0].Interacted {
+		t.Error("player didn't interact through a see-through box")
+	}
+}
+
+// A disabled box doesn't block the interaction
+func TestInteractThroughDisabledBox(t *testing.T) {
+	world := newInteractTestWorld()
+	world.AddInteractableBox(getLookTestBox(world, 2.5, .2))
+	wall := world.AddBoundingBox(getLookTestBox(world, 1.5, .1))
+	world.SetBoundingBoxEnabled(wall, false)
+
+	stepTestWorld(world, 1, getTestInputs(ControlInteract))
+	if !world.InteractableBoxes[0].Interacted {
+		t.Error("player didn't interact through a disabled box")
+	}
+}
+
+// Only the closest of overlapping interactable boxes is focused and interacted with
+func TestInteractClosestBox(t *testing.T) {
+	world := newInteractTestWorld()
+	world.AddInteractableBox(getLookTestBox(world, 2.5, .3))
+	near := world.AddInteractableBox(getLookTestBox(world, 1.5, .1))
+
+	stepTestWorld(world, 1, getTestInputs(ControlInteract))
+	focus, ok := world.FocusedInteractable()
+	if !ok || focus.ID != near {
+		t.Fatalf("focused %+v, want the near box %d", focus, near)
+	}
+	if focus.Distance < 1.39 || focus.Distance > 1.41 {
+		t.Errorf("focused box is %g far, want 1.4", focus.Distance)
+	}
+	if world.InteractableBoxes[0].Interacting || !world.InteractableBoxes[1].Interacting {
+		t.Error("player interacted with the far box")
+	}
+}
+
+// Nothing is focused when the player doesn't look at an interactable box
+func TestInteractNoFocus(t *testing.T) {
+	world := newInteractTestWorld()
+	world.AddInteractableBox(rl.BoundingBox{Min: rl.Vector3{X: 5., Y: 0., Z: 5.}, Max: rl.Vector3{X: 6., Y: 1., Z: 6.}})
+
+	stepTestWorld(world, 1, [ControlCount]bool{})
+	if focus, ok := world.FocusedInteractable(); ok {
+		t.Errorf("focused %+v without looking at a box", focus)
+	}
+}
+
+// Steps a world by frames of .016 seconds with the same inputs and takes the types of the events
+//
+// #1 argument world: *World - the world
+//
+// #2 argument frames: int - number of frames
+//
+// #3 argument inputs: [ControlCount]bool - the inputs
+//
+// #1 return: [][]int - the types of the events of every frame
+func stepHoldTestWorld(world *World, frames int, inputs [ControlCount]bool) [][]int {
+	types := make([][]int, frames)
+	for i := range types {
+		world.Step(.016, inputs, rl.Vector2{X: 0., Y: 0.})
+		for _, event := range world.PollEvents() {
+			types[i] = append(types[i], event.Type)
+		}
+	}
+
+	return types
+}
+
+// Holding the interact key for the hold duration completes the interaction once
+func TestInteractHold(t *testing.T) {
+	world := newInteractTestWorld()
+	id := world.AddInteractableBox(getLookTestBox(world, 1.5, .2))
+	world.SetInteractableBoxHoldDuration(id, .1)
+
+	interacted := []int{}
+	types := make([][]int, 0, 10)
+	for i := 0; i < 10; i++ {
+		types = append(types, stepHoldTestWorld(world, 1, getTestInputs(ControlInteract))[0])
+		if world.InteractableBoxes[0].Interacted {
+			interacted = append(interacted, i)
+		}
+		if i == 2 && (world.InteractableBoxes[0].GetHoldProgress() <= 0. || world.InteractableBoxes[0].GetHoldProgress() >= 1.) {
+			t.Errorf("hold progress is %g in the middle of holding", world.InteractableBoxes[0].GetHoldProgress())
+		}
+	}
+
+	if len(interacted) != 1 || interacted[0] != 6 {
+		t.Errorf("box was interacted in frames %v, want only in frame 6", interacted)
+	}
+	if len(types[0]) != 1 || types[0][0] != EventInteractStarted {
+		t.Errorf("first frame sent %v, want the start", types[0])
+	}
+	if len(types[6]) != 2 || types[6][0] != EventInteract || types[6][1] != EventInteractCompleted {
+		t.Errorf("completing frame sent %v, want the interaction and the completion", types[6])
+	}
+	for i := 7; i < 10; i++ {
+		if len(types[i]) != 0 {
+			t.Errorf("frame %d after the completion sent %v", i, types[i])
+		}
+	}
+}
+
+// Releasing the interact key before the hold duration cancels the interaction
+func TestInteractHoldCancel(t *testing.T) {
+	world := newInteractTestWorld()
+	id := world.AddInteractableBox(getLookTestBox(world, 1.5, .2))
+	world.SetInteractableBoxHoldDuration(id, .5)
+
+	stepHoldTestWorld(world, 10, getTestInputs(ControlInteract))
+	types := stepHoldTestWorld(world, 1, [ControlCount]bool{})
+	if len(types[0]) != 1 || types[0][0] != EventInteractCancelled {
+		t.Errorf("releasing the key sent %v, want the cancellation", types[0])
+	}
+	if world.InteractableBoxes[0].GetHoldProgress() != 0. {
+		t.Errorf("hold progress is %g after cancelling", world.InteractableBoxes[0].GetHoldProgress())
+	}
+}
+
+// Every verb fires with its own control and the box can't be used again during the cooldown
+func TestInteractVerbsAndCooldown(t *testing.T) {
+	world := newInteractTestWorld()
+	id := world.AddInteractableBox(getLookTestBox(world, 1.5, .2))
+	world.SetInteractableBoxVerbs(id, []InteractVerb{{Name: "use", Control: ControlInteract}, {Name: "inspect", Control: ControlInteract2}})
+	world.SetInteractableBoxCooldown(id, .1)
+	world.SetInteractableBoxPrompt(id, "lever", "Press E to pull")
+
+	use, inspect, none := getTestInputs(ControlInteract), getTestInputs(ControlInteract2), [ControlCount]bool{}
+	verbs := []string{}
+	for _, inputs := range [][ControlCount]bool{use, use, none, use, none, none, none, none, none, none, none, none, inspect, inspect, none, inspect} {
+		world.Step(.016, inputs, rl.Vector2{X: 0., Y: 0.})
+		for _, event := range world.PollEvents() {
+			if event.Type == EventInteract {
+				verbs = append(verbs, event.Verb)
+			}
+		}
+	}
+
+	if len(verbs) != 2 || verbs[0] != "use" || verbs[1] != "inspect" {
+		t.Errorf("used verbs %v, want use and inspect once", verbs)
+	}
+	if box, _ := world.GetInteractableBox(id); box.Name != "lever" || box.Prompt != "Press E to pull" {
+		t.Errorf("box is named %q with prompt %q", box.Name, box.Prompt)
+	}
+}
+
+// The player can't interact with a disabled box
+func TestInteractDisabledBox(t *testing.T) {
+	world := newInteractTestWorld()
+	id := world.AddInteractableBox(getLookTestBox(world, 1.5, .2))
+	world.SetInteractableBoxEnabled(id, false)
+
+	stepTestWorld(world, 1, getTestInputs(ControlInteract))
+	if _, ok := world.FocusedInteractable(); ok || world.InteractableBoxes[0].Interacted {
+		t.Error("player interacted with a disabled box")
+	}
+
+	world.SetInteractableBoxEnabled(id, true)
+	stepTestWorld(world, 1, [ControlCount]bool{})
+	stepTestWorld(world, 1, getTestInputs(ControlInteract))
+	if !world.InteractableBoxes[0].Interacted {
+		t.Error("player didn't interact with the enabled box")
+	}
+}
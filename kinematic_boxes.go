@@ -0,0 +1,348 @@
+package rlfp
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Path of a kinematic bounding box going through waypoints, used for elevators, lifts and moving platforms
+type KinematicPath struct {
+	// Positions of the box's minimum corner the box moves through
+	Waypoints []rl.Vector3
+	// How fast the box moves along the path in units per second
+	Speed float32
+	// If the box goes from the last waypoint to the first one, otherwise it goes back and forth
+	Loop bool
+	// How long the box waits at every waypoint in seconds
+	Pause float32
+	// Index of the waypoint the box is moving to
+	Target int
+	// If the box is going back to the first waypoint (when it's not looping)
+	Reverse bool
+	// Time left until the box leaves the waypoint it's waiting at in seconds
+	PauseTime float32
+}
+
+// Sets the velocity of a bounding box, a box with a velocity is kinematic, it moves, carries and pushes the player
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #2 argument velocity: rl.Vector3 - the velocity in units per second, zero makes the box static again, a dynamic box is thrown with it instead
+//
+// #1 return: bool - false if there is no bounding box with the ID
+func (world *World) SetBoundingBoxVelocity(id BoxID, velocity rl.Vector3) bool {
+	i := world.getBoxIndex(&world.bounding_box_indexes, len(world.BoundingBoxes), id)
+	if i == -1 {
+		return false
+	}
+
+	world.BoundingBoxes[i].Velocity = velocity
+	world.BoundingBoxes[i].Resting = false
+	world.updateKinematicList(i)
+
+	return true
+}
+
+// Sets the path of a bounding box, a box with a path is kinematic, it moves, carries and pushes the player
+// The path is used instead of the box's velocity
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #2 argument path: KinematicPath - the path, a path without waypoints makes the box use its velocity again
+//
+// #1 return: bool - false if there is no bounding box with the ID
+func (world *World) SetBoundingBoxPath(id BoxID, path KinematicPath) bool {
+	i := world.getBoxIndex(&world.bounding_box_indexes, len(world.BoundingBoxes), id)
+	if i == -1 {
+		return false
+	}
+
+	path.Waypoints = append([]rl.Vector3{}, path.Waypoints...)
+	world.BoundingBoxes[i].Path = path
+	world.updateKinematicList(i)
+
+	return true
+}
+
+// Checks if the box moves by itself
+//
+// #1 return: bool - true if the box isn't dynamic and has a velocity or a path
+func (box *CollisionBox) isKinematic() bool {
+	return !box.Dynamic && (len(box.Path.Waypoints) > 0 || box.Velocity.X != 0. || box.Velocity.Y != 0. || box.Velocity.Z != 0.)
+}
+
+// Adds a bounding box to the list of kinematic boxes or removes it from there
+//
+// #1 argument i: int - index of the bounding box
+func (world *World) updateKinematicList(i int) {
+	world.kinematic_boxes = removeKey(world.kinematic_boxes, i)
+	if world.BoundingBoxes[i].isKinematic() {
+		world.kinematic_boxes = append(world.kinematic_boxes, i)
+	}
+}
+
+// Moves every kinematic bounding box and carries or pushes the player with them, called before the player is updated
+func (world *World) UpdateKinematicBoxes() {
+	if !world.bounding_box_grid.isInitialized() || world.bounding_box_grid.count != len(world.BoundingBoxes) {
+		world.RebuildSpatialGrids()
+	}
+
+	for _, i := range world.kinematic_boxes {
+		world.UpdateKinematicBox(i)
+	}
+}
+
+// Moves a kinematic bounding box, the box stops for the frame when it would squash the player into another box
+//
+// #1 argument i: int - index of the bounding box
+func (world *World) UpdateKinematicBox(i int) {
+	box := &world.BoundingBoxes[i]
+	old_box := box.BoundingBox
+	old_path := box.Path
+
+	var offset rl.Vector3
+	if len(box.Path.Waypoints) > 0 {
+		offset = box.Path.step(box.BoundingBox.Min, world.FrameTime)
+	} else {
+		offset = rl.Vector3Scale(box.Velocity, world.FrameTime)
+	}
+	if offset.X == 0. && offset.Y == 0. && offset.Z == 0. {
+		return
+	}
+
+	is_riding := !box.Disabled && world.isPlayerRiding(box.BoundingBox)
+	old_player_box := world.Player.BoundingBox
+
+	world.moveBoundingBox(i, rl.Vector3Add(old_box.Min, offset))
+
+	// Carry the player standing on the box, the player stays behind on the axes where something is in the way
+	if is_riding {
+		world.carryPlayer(i, offset)
+	}
+
+	// Push the player out of the box in the direction the box is moving
+	if box.Disabled || !world.Player.getMovement().HasCollisions() || !rl.CheckCollisionBoxes(world.Player.BoundingBox, box.BoundingBox) {
+		return
+	}
+	push, ok := getKinematicPush(world.Player.BoundingBox, box.BoundingBox, offset, world.FloatPrecision)
+	if ok {
+		pushed := moveBox(world.Player.BoundingBox, push)
+		if pushed.Min.Y >= world.Ground && !world.isPlayerBlocked(pushed, i) {
+			world.setPlayerBoundingBox(pushed)
+			if push.Y > 0. && world.Player.YVelocity < 0. {
+				world.Player.YVelocity = 0.
+			}
+			return
+		}
+	}
+
+	// The player would be squashed, so the box stops for this frame
+	world.moveBoundingBox(i, old_box.Min)
+	world.BoundingBoxes[i].Path = old_path
+	world.setPlayerBoundingBox(old_player_box)
+	world.pushEvent(Event{
+		Type:      EventSquash,
+		BoxID:     world.BoundingBoxes[i].ID,
+		Entity:    Entity{Kind: EntityPlayer, ID: 0},
+		FrameTime: world.FrameTime,
+	})
+}
+
+// Moves the player standing on a kinematic box with the box
+//
+// #1 argument i: int - index of the kinematic bounding box
+//
+// #2 argument offset: rl.Vector3 - how far the box moved
+func (world *World) carryPlayer(i int, offset rl.Vector3) {
+	// Y first, so the player isn't pushed into the box when it moves up
+	axes := [3]rl.Vector3{{X: 0., Y: offset.Y, Z: 0.}, {X: offset.X, Y: 0., Z: 0.}, {X: 0., Y: 0., Z: offset.Z}}
+	for _, axis := range axes {
+		if axis.X == 0. && axis.Y == 0. && axis.Z == 0. {
+			continue
+		}
+
+		moved := moveBox(world.Player.BoundingBox, axis)
+		if moved.Min.Y >= world.Ground && !world.isPlayerBlocked(moved, i) {
+			world.setPlayerBoundingBox(moved)
+		}
+	}
+}
+
+// Checks if the player is standing on top of a box
+//
+// #1 argument box: rl.BoundingBox - the box
+//
+// #1 return: bool - true if the bottom of the player touches the top of the box, false if the player doesn't collide with the world
+func (world *World) isPlayerRiding(box rl.BoundingBox) bool {
+	if !world.Player.getMovement().HasCollisions() {
+		return false
+	}
+
+	player_box := world.Player.BoundingBox
+	tolerance := world.FloatPrecision * 10.
+
+	return player_box.Min.Y >= box.Max.Y-tolerance && player_box.Min.Y <= box.Max.Y+tolerance &&
+		player_box.Min.X < box.Max.X && player_box.Max.X > box.Min.X &&
+		player_box.Min.Z < box.Max.Z && player_box.Max.Z > box.Min.Z
+}
+
+// Checks if the player's bounding box would collide with an active bounding box or collider
+//
+// #1 argument player_box: rl.BoundingBox - the player's bounding box
+//
+// #2 argument ignored: int - index of the bounding box that isn't checked
+//
+// #1 return: bool - true if there is a collision
+func (world *World) isPlayerBlocked(player_box rl.BoundingBox, ignored int) bool {
+	for _, i := range world.queryBoundingBoxes(player_box) {
+		if i != ignored && world.isBoundingBoxActive(i) && world.isBoundingBoxColliding(i, player_box) {
+			return true
+		}
+	}
+
+	return world.isColliding(player_box)
+}
+
+// Gets the offset that moves the player out of a kinematic box on the axis of the box's movement that needs the shortest push
+//
+// #1 argument player_box: rl.BoundingBox - the player's bounding box
+//
+// #2 argument box: rl.BoundingBox - the kinematic box
+//
+// #3 argument offset: rl.Vector3 - how far the box moved
+//
+// #4 argument precision: float32 - gap left between the player and the box
+//
+// #1 return: rl.Vector3 - the push
+//
+// #2 return: bool - false if the box didn't move on any axis
+func getKinematicPush(player_box rl.BoundingBox, box rl.BoundingBox, offset rl.Vector3, precision float32) (rl.Vector3, bool) {
+	push := rl.Vector3{X: 0., Y: 0., Z: 0.}
+	shortest := float32(-1.)
+
+	try := func(distance float32, axis rl.Vector3) {
+		if shortest < 0. || distance < shortest {
+			shortest = distance
+			push = rl.Vector3Scale(axis, distance)
+		}
+	}
+
+	if offset.X > 0. {
+		try(box.Max.X+precision-player_box.Min.X, rl.Vector3{X: 1., Y: 0., Z: 0.})
+	} else if offset.X < 0. {
+		try(player_box.Max.X-box.Min.X+precision, rl.Vector3{X: -1., Y: 0., Z: 0.})
+	}
+	if offset.Y > 0. {
+		try(box.Max.Y+precision-player_box.Min.Y, rl.Vector3{X: 0., Y: 1., Z: 0.})
+	} else if offset.Y < 0. {
+		try(player_box.Max.Y-box.Min.Y+precision, rl.Vector3{X: 0., Y: -1., Z: 0.})
+	}
+	if offset.Z > 0. {
+		try(box.Max.Z+precision-player_box.Min.Z, rl.Vector3{X: 0., Y: 0., Z: 1.})
+	} else if offset.Z < 0. {
+		try(player_box.Max.Z-box.Min.Z+precision, rl.Vector3{X: 0., Y: 0., Z: -1.})
+	}
+
+	return push, shortest >= 0.
+}
+
+// Moves a bounding box in the world and keeps the spatial grid in sync
+//
+// #1 argument i: int - index of the bounding box
+//
+// #2 argument min: rl.Vector3 - new position of the box's minimum corner
+func (world *World) moveBoundingBox(i int, min rl.Vector3) {
+	box := world.BoundingBoxes[i].BoundingBox
+
+	world.bounding_box_grid.remove(i)
+	world.BoundingBoxes[i].BoundingBox = moveBox(box, rl.Vector3Subtract(min, box.Min))
+	world.BoundingBoxes[i].Oriented = world.BoundingBoxes[i].Oriented.Move(rl.Vector3Subtract(min, box.Min))
+	world.bounding_box_grid.insert(i, world.BoundingBoxes[i].BoundingBox)
+}
+
+// Sets the player's bounding box and moves the player's position to its center
+//
+// #1 argument box: rl.BoundingBox - the new bounding box, it has to be the same size
+func (world *World) setPlayerBoundingBox(box rl.BoundingBox) {
+	world.Player.Position = rl.Vector3Add(box.Min, rl.Vector3Scale(world.Player.Scale, .5))
+	world.Player.BoundingBox = box
+}
+
+// Moves a box by an offset
+//
+// #1 argument box: rl.BoundingBox - the box
+//
+// #2 argument offset: rl.Vector3 - the offset
+//
+// #1 return: rl.BoundingBox - the moved box
+func moveBox(box rl.BoundingBox, offset rl.Vector3) rl.BoundingBox {
+	return rl.BoundingBox{
+		Min: rl.Vector3Add(box.Min, offset),
+		Max: rl.Vector3Add(box.Max, offset),
+	}
+}
+
+// Advances the path by one frame
+//
+// #1 argument position: rl.Vector3 - current position of the box's minimum corner
+//
+// #2 argument frame_time: float32 - time of the frame in seconds
+//
+// #1 return: rl.Vector3 - how far the box moves
+func (path *KinematicPath) step(position rl.Vector3, frame_time float32) rl.Vector3 {
+	if path.PauseTime > 0. {
+		path.PauseTime -= frame_time
+		return rl.Vector3{X: 0., Y: 0., Z: 0.}
+	}
+	if path.Target < 0 || path.Target >= len(path.Waypoints) {
+		path.Target = 0
+	}
+
+	current := position
+	remaining := path.Speed * frame_time
+	// Every waypoint is reached at most once per frame, so waypoints at the same position can't loop forever
+	for attempt := 0; attempt < len(path.Waypoints); attempt++ {
+		to_target := rl.Vector3Subtract(path.Waypoints[path.Target], current)
+		distance := rl.Vector3Length(to_target)
+
+		if distance > remaining {
+			current = rl.Vector3Add(current, rl.Vector3Scale(to_target, remaining/distance))
+			break
+		}
+
+		current = path.Waypoints[path.Target]
+		remaining -= distance
+		path.nextTarget()
+
+		if path.Pause > 0. {
+			path.PauseTime = path.Pause
+			break
+		}
+	}
+
+	return rl.Vector3Subtract(current, position)
+}
+
+// Sets the target to the next waypoint of the path
+func (path *KinematicPath) nextTarget() {
+	last := len(path.Waypoints) - 1
+	if last == 0 {
+		return
+	}
+
+	if path.Loop {
+		path.Target = (path.Target + 1) % len(path.Waypoints)
+		return
+	}
+
+	if path.Reverse && path.Target == 0 {
+		path.Reverse = false
+	} else if !path.Reverse && path.Target == last {
+		path.Reverse = true
+	}
+
+	if path.Reverse {
+		path.Target--
+	} else {
+		path.Target++
+	}
+}
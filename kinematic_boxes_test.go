@@ -0,0 +1,76 @@
+package rlfp
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"github.com/chewxy/math32"
+)
+
+// Creates a world with the player standing on a lift, which goes up 3 units at the speed 1 and waits .5 seconds at the ends
+//
+// #1 return: *World - the new world
+//
+// #2 return: BoxID - ID of the lift
+func newLiftTestWorld() (*World, BoxID) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 2., Z: 0.})
+	lift := world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: -1., Y: 0., Z: -1.}, Max: rl.Vector3{X: 1., Y: .5, Z: 1.}})
+	world.SetBoundingBoxPath(lift, KinematicPath{Waypoints: []rl.Vector3{{X: -1., Y: 0., Z: -1.}, {X: -1., Y: 3., Z: -1.}}, Speed: 1., Pause: .5})
+
+	return world, lift
+}
+
+// A lift carries the player standing on it
+func TestLiftCarriesPlayer(t *testing.T) {
+	world, lift := newLiftTestWorld()
+
+	for i := 0; i < 150; i++ {
+		stepTestWorld(world, 1, [ControlCount]bool{})
+		box, _ := world.GetBoundingBox(lift)
+		if i >= 30 && math32.Abs(world.Player.BoundingBox.Min.Y-box.BoundingBox.Max.Y) > .01 {
+			t.Fatalf("player is at Y %g on a lift at Y %g in frame %d", world.Player.BoundingBox.Min.Y, box.BoundingBox.Max.Y, i)
+		}
+	}
+
+	if box, _ := world.GetBoundingBox(lift); box.BoundingBox.Max.Y < 2.4 {
+		t.Errorf("lift only got to Y %g", box.BoundingBox.Max.Y)
+	}
+}
+
+// A lift stops instead of squashing the player into a ceiling
+func TestLiftSquash(t *testing.T) {
+	world, lift := newLiftTestWorld()
+	world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: -3., Y: 4., Z: -3.}, Max: rl.Vector3{X: 3., Y: 5., Z: 3.}})
+
+	stepTestWorld(world, 300, [ControlCount]bool{})
+	squashes := 0
+	for _, event := range world.PollEvents() {
+		if event.Type == EventSquash && event.BoxID == lift {
+			squashes++
+		}
+	}
+
+	if squashes == 0 {
+		t.Error("lift didn't send a squash event")
+	}
+	if world.Player.BoundingBox.Max.Y > 4.001 {
+		t.Errorf("player was pushed into the ceiling to Y %g", world.Player.BoundingBox.Max.Y)
+	}
+	if box, _ := world.GetBoundingBox(lift); world.Player.BoundingBox.Min.Y < box.BoundingBox.Max.Y-.001 {
+		t.Errorf("lift at Y %g went into the player at Y %g", box.BoundingBox.Max.Y, world.Player.BoundingBox.Min.Y)
+	}
+}
+
+// A moving wall pushes the player
+func TestMovingWallPushesPlayer(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 3., Y: 1., Z: 0.})
+	wall := world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: -1., Y: 0., Z: -1.}, Max: rl.Vector3{X: 0., Y: 2., Z: 1.}})
+	world.SetBoundingBoxVelocity(wall, rl.Vector3{X: 2., Y: 0., Z: 0.})
+
+	stepTestWorld(world, 120, [ControlCount]bool{})
+	box, _ := world.GetBoundingBox(wall)
+	if box.BoundingBox.Max.X < 3.9 || world.Player.BoundingBox.Min.X < box.BoundingBox.Max.X-.001 {
+		t.Errorf("wall moved to X %g and the player is at X %g, want the player in front of the wall", box.BoundingBox.Max.X, world.Player.BoundingBox.Min.X)
+	}
+}
@@ -0,0 +1,344 @@
+package rlfp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Kinds of boxes in a level
+const (
+	LevelBoundingBox = iota
+	LevelTriggerBox
+	LevelInteractableBox
+)
+
+// Level loaded from a level file
+//
+// Level files are text files with one statement per line, empty lines and lines starting with # are ignored:
+//
+//	ground <y>
+//	gravity <value>
+//	spawn <x> <y> <z> [<rotation x> <rotation y>] [crouch]
+//	box <min x> <min y> <min z> <max x> <max y> <max z> [name=<name>] [tags=<tag>,<tag>...]
+//	trigger <min x> <min y> <min z> <max x> <max y> <max z> [name=<name>] [tags=<tag>,<tag>...]
+//	interactable <min x> <min y> <min z> <max x> <max y> <max z> [name=<name>] [tags=<tag>,<tag>...]
+//
+// The spawn statement is required, ground and gravity keep the world's values when they are missing.
+type Level struct {
+	// Height of the ground, nil if the level doesn't set it
+	Ground *float32
+	// Gravity of the world, nil if the level doesn't set it
+	Gravity *float32
+	// Where the player spawns
+	SpawnPosition rl.Vector3
+	SpawnRotation rl.Vector2
+	// If the player spawns crouching
+	SpawnCrouching bool
+	// Every box of the level in the order of the file
+	Boxes []LevelBox
+}
+
+// Box of a level
+type LevelBox struct {
+	// Kind of the box (LevelBoundingBox, LevelTriggerBox, LevelInteractableBox)
+	Kind int
+	Box  rl.BoundingBox
+	// Unique name of the box, can be empty
+	Name string
+	Tags []string
+	// Line of the level file, where the box is
+	Line int
+	// ID of the box in the world after loading the level
+	ID BoxID
+}
+
+// Returned when a level file is not valid
+type LevelError struct {
+	// Line of the level file with the error
+	Line    int
+	Message string
+}
+
+// Describes the error with its line
+//
+// #1 return: string - the error message
+func (err *LevelError) Error() string {
+	return fmt.Sprintf("rlfp: level line %d: %s", err.Line, err.Message)
+}
+
+// Loads a level file into the world, every box in the world is replaced by the level's boxes and the player is spawned
+// The level is validated before the world is changed
+//
+// #1 argument reader: io.Reader - where the level file is read from
+//
+// #1 return: *Level - the loaded level, used for finding boxes by name or tag
+//
+// #2 return: error - *LevelError if the level file is not valid
+func (world *World) LoadLevel(reader io.Reader) (*Level, error) {
+	level, err := ParseLevel(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if level.Ground != nil {
+		world.Ground = *level.Ground
+	}
+	if level.Gravity != nil {
+		world.Gravity = *level.Gravity
+	}
+	world.New(level.SpawnPosition, level.SpawnRotation, level.SpawnCrouching)
+
+	for i := range level.Boxes {
+		switch level.Boxes[i].Kind {
+		case LevelBoundingBox:
+			level.Boxes[i].ID = world.AddBoundingBox(level.Boxes[i].Box)
+		case LevelTriggerBox:
+			level.Boxes[i].ID = world.AddTriggerBox(level.Boxes[i].Box)
+		case LevelInteractableBox:
+			level.Boxes[i].ID = world.AddInteractableBox(level.Boxes[i].Box)
+			// The name of an interactable box is reported in its events
+			world.InteractableBoxes[len(world.InteractableBoxes)-1].Name = level.Boxes[i].Name
+		}
+	}
+
+	return level, nil
+}
+
+// Parses and validates a level file without loading it into a world
+//
+// #1 argument reader: io.Reader - where the level file is read from
+//
+// #1 return: *Level - the parsed level
+//
+// #2 return: error - *LevelError if the level file is not valid
+func ParseLevel(reader io.Reader) (*Level, error) {
+	level := &Level{Boxes: []LevelBox{}}
+	names := map[string]int{}
+	spawn_line := 0
+	ground_line := 0
+	gravity_line := 0
+
+	scanner := bufio.NewScanner(reader)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		switch fields[0] {
+		case "ground", "gravity":
+			if len(fields) != 2 {
+				return nil, &LevelError{line, fmt.Sprintf("%s needs 1 value, got %d", fields[0], len(fields)-1)}
+			}
+			value, err := parseLevelFloat(fields[1], line)
+			if err != nil {
+				return nil, err
+			}
+
+			if fields[0] == "ground" {
+				if ground_line != 0 {
+					return nil, &LevelError{line, fmt.Sprintf("ground is already set on line %d", ground_line)}
+				}
+				ground_line = line
+				level.Ground = &value
+			} else {
+				if gravity_line != 0 {
+					return nil, &LevelError{line, fmt.Sprintf("gravity is already set on line %d", gravity_line)}
+				}
+				gravity_line = line
+				level.Gravity = &value
+			}
+		case "spawn":
+			if spawn_line != 0 {
+				return nil, &LevelError{line, fmt.Sprintf("spawn is already set on line %d", spawn_line)}
+			}
+			spawn_line = line
+
+			values := fields[1:]
+			if len(values) > 0 && values[len(values)-1] == "crouch" {
+				level.SpawnCrouching = true
+				values = values[:len(values)-1]
+			}
+			if len(values) != 3 && len(values) != 5 {
+				return nil, &LevelError{line, fmt.Sprintf("spawn needs 3 or 5 values, got %d", len(values))}
+			}
+
+			numbers, err := parseLevelFloats(values, line)
+			if err != nil {
+				return nil, err
+			}
+			level.SpawnPosition = rl.Vector3{X: numbers[0], Y: numbers[1], Z: numbers[2]}
+			if len(numbers) == 5 {
+				level.SpawnRotation = rl.Vector2{X: numbers[3], Y: numbers[4]}
+			}
+		case "box", "trigger", "interactable":
+			box, err := parseLevelBox(fields, line)
+			if err != nil {
+				return nil, err
+			}
+
+			if box.Name != "" {
+				if name_line, ok := names[box.Name]; ok {
+					return nil, &LevelError{line, fmt.Sprintf("name %q is already used on line %d", box.Name, name_line)}
+				}
+				names[box.Name] = line
+			}
+
+			level.Boxes = append(level.Boxes, box)
+		default:
+			return nil, &LevelError{line, fmt.Sprintf("unknown statement %q", fields[0])}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, &LevelError{line + 1, err.Error()}
+	}
+
+	if spawn_line == 0 {
+		return nil, &LevelError{line, "missing spawn statement"}
+	}
+
+	return level, nil
+}
+
+// Parses a box statement of a level file
+//
+// #1 argument fields: []string - the fields of the line, starting with the statement
+//
+// #2 argument line: int - number of the line
+//
+// #1 return: LevelBox - the parsed box
+//
+// #2 return: error - *LevelError if the box is not valid
+func parseLevelBox(fields []string, line int) (LevelBox, error) {
+	box := LevelBox{Tags: []string{}, Line: line}
+	switch fields[0] {
+	case "box":
+		box.Kind = LevelBoundingBox
+	case "trigger":
+		box.Kind = LevelTriggerBox
+	case "interactable":
+		box.Kind = LevelInteractableBox
+	}
+
+	if len(fields) < 7 {
+		return box, &LevelError{line, fmt.Sprintf("%s needs 6 values, got %d", fields[0], len(fields)-1)}
+	}
+
+	numbers, err := parseLevelFloats(fields[1:7], line)
+	if err != nil {
+		return box, err
+	}
+	box.Box = rl.BoundingBox{
+		Min: rl.Vector3{X: numbers[0], Y: numbers[1], Z: numbers[2]},
+		Max: rl.Vector3{X: numbers[3], Y: numbers[4], Z: numbers[5]},
+	}
+	if box.Box.Min.X > box.Box.Max.X || box.Box.Min.Y > box.Box.Max.Y || box.Box.Min.Z > box.Box.Max.Z {
+		return box, &LevelError{line, fmt.Sprintf("%s minimum is bigger than its maximum", fields[0])}
+	}
+
+	// Attributes after the values
+	for _, field := range fields[7:] {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok || value == "" {
+			return box, &LevelError{line, fmt.Sprintf("expected key=value, got %q", field)}
+		}
+
+		switch key {
+		case "name":
+			box.Name = value
+		case "tags":
+			for _, tag := range strings.Split(value, ",") {
+				if tag == "" {
+					return box, &LevelError{line, "empty tag"}
+				}
+				box.Tags = append(box.Tags, tag)
+			}
+		default:
+			return box, &LevelError{line, fmt.Sprintf("unknown attribute %q", key)}
+		}
+	}
+
+	return box, nil
+}
+
+// Parses a list of numbers of a level file
+//
+// #1 argument values: []string - the numbers to parse
+//
+// #2 argument line: int - number of the line
+//
+// #1 return: []float32 - the parsed numbers
+//
+// #2 return: error - *LevelError if a value is not a number
+func parseLevelFloats(values []string, line int) ([]float32, error) {
+	numbers := make([]float32, len(values))
+	for i := range values {
+		number, err := parseLevelFloat(values[i], line)
+		if err != nil {
+			return nil, err
+		}
+		numbers[i] = number
+	}
+
+	return numbers, nil
+}
+
+// Parses a number of a level file
+//
+// #1 argument value: string - the number to parse
+//
+// #2 argument line: int - number of the line
+//
+// #1 return: float32 - the parsed number
+//
+// #2 return: error - *LevelError if the value is not a number
+func parseLevelFloat(value string, line int) (float32, error) {
+	number, err := strconv.ParseFloat(value, 32)
+	if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0., &LevelError{line, fmt.Sprintf("%q is not a number", value)}
+	}
+
+	return float32(number), nil
+}
+
+// Finds a box by its name
+//
+// #1 argument name: string - name of the box
+//
+// #1 return: *LevelBox - the box, nil if there is no box with the name
+func (level *Level) Find(name string) *LevelBox {
+	for i := range level.Boxes {
+		if level.Boxes[i].Name == name {
+			return &level.Boxes[i]
+		}
+	}
+
+	return nil
+}
+
+// Finds every box with a tag
+//
+// #1 argument tag: string - the tag to look for
+//
+// #1 return: []*LevelBox - the boxes with the tag in the order of the file
+func (level *Level) FindTagged(tag string) []*LevelBox {
+	boxes := []*LevelBox{}
+	for i := range level.Boxes {
+		for _, box_tag := range level.Boxes[i].Tags {
+			if box_tag == tag {
+				boxes = append(boxes, &level.Boxes[i])
+				break
+			}
+		}
+	}
+
+	return boxes
+}
@@ -0,0 +1,78 @@
+package rlfp
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// A level replaces the world's boxes, spawns the player and its boxes are found by name and tag
+func TestLoadLevel(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: 5., Y: 0., Z: 5.}, Max: rl.Vector3{X: 6., Y: 1., Z: 6.}})
+
+	level, err := world.LoadLevel(strings.NewReader(`# test level
+ground 0
+gravity 12
+spawn 0 1 0 1.5 0
+
+box 2 0 0 3 .4 1 name=step tags=stairs,wood
+box 3 0 0 4 .8 1 tags=stairs
+trigger -1 0 5 0 1 6 name=door
+interactable -1 0 -6 0 1 -5 name=lever
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if world.Gravity != 12. || world.Player.Rotation.X != 1.5 {
+		t.Errorf("world has gravity %g and rotation %v, want 12 and X 1.5", world.Gravity, world.Player.Rotation)
+	}
+	if len(world.BoundingBoxes) != 2 || len(world.TriggerBoxes) != 1 || len(world.InteractableBoxes) != 1 {
+		t.Fatalf("world has %d bounding, %d trigger and %d interactable boxes, want 2, 1 and 1", len(world.BoundingBoxes), len(world.TriggerBoxes), len(world.InteractableBoxes))
+	}
+
+	if box := level.Find("door"); box == nil || box.Kind != LevelTriggerBox || box.ID != world.TriggerBoxes[0].ID {
+		t.Errorf("found door %+v, want the trigger box", box)
+	}
+	if level.Find("missing") != nil {
+		t.Error("found a box with a missing name")
+	}
+	if boxes := level.FindTagged("stairs"); len(boxes) != 2 {
+		t.Errorf("found %d boxes tagged stairs, want 2", len(boxes))
+	}
+	if world.InteractableBoxes[0].Name != "lever" {
+		t.Errorf("interactable box is named %q, want lever", world.InteractableBoxes[0].Name)
+	}
+}
+
+// Invalid level files report their line and don't change the world
+func TestLoadLevelErrors(t *testing.T) {
+	levels := map[string]int{
+		"spawn 0 0 0\nbox 1 2 3\n":     2,
+		"spawn 0 0 0\nbox 0 0 0 1 1 x": 2,
+		"ground 1\n\nfoo":              3,
+		"spawn 0 0 0\nbox 0 0 0 1 1 1 name=a\nbox 0 0 0 1 1 1 name=a": 3,
+		"ground x": 1,
+	}
+
+	for source, line := range levels {
+		world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+		world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: 5., Y: 0., Z: 5.}, Max: rl.Vector3{X: 6., Y: 1., Z: 6.}})
+
+		_, err := world.LoadLevel(strings.NewReader(source))
+		level_error := &LevelError{}
+		if !errors.As(err, &level_error) || level_error.Line != line {
+			t.Errorf("level %q returned %v, want an error at line %d", source, err, line)
+		}
+		if len(world.BoundingBoxes) != 1 {
+			t.Errorf("level %q changed the world", source)
+		}
+	}
+
+	if _, err := ParseLevel(strings.NewReader("box 0 0 0 1 1 1")); err == nil {
+		t.Error("level without a spawn was parsed")
+	}
+}
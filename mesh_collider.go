@@ -0,0 +1,390 @@
+package rlfp
+
+import (
+	"unsafe"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"github.com/chewxy/math32"
+)
+
+// Size of one cell of the grid used for finding the triangles of a mesh collider near an area
+const meshColliderCellSize = 2.
+
+// Static triangle mesh collider, e.g. the geometry of a level, triangles collide from both sides
+type MeshCollider struct {
+	// Triangles of the mesh in world space, MeshCollider.Init has to be called after changing them
+	Triangles [][3]rl.Vector3
+
+	bounding_box rl.BoundingBox
+	grid         spatialGrid
+	query        []int
+}
+
+// Initializes the collider from triangles in world space
+//
+// #1 argument triangles: [][3]rl.Vector3 - the triangles
+func (mesh *MeshCollider) Init(triangles [][3]rl.Vector3) {
+	mesh.Triangles = triangles
+	mesh.grid.init(meshColliderCellSize)
+	mesh.bounding_box = rl.BoundingBox{}
+
+	for i, triangle := range mesh.Triangles {
+		box := getTriangleBoundingBox(triangle)
+		mesh.grid.insert(i, box)
+
+		if i == 0 {
+			mesh.bounding_box = box
+		} else {
+			mesh.bounding_box.Min = rl.Vector3Min(mesh.bounding_box.Min, box.Min)
+			mesh.bounding_box.Max = rl.Vector3Max(mesh.bounding_box.Max, box.Max)
+		}
+	}
+}
+
+// Initializes the collider from the triangles of a raylib mesh, the vertices have to be in CPU memory
+//
+// #1 argument mesh_data: rl.Mesh - the mesh
+//
+// #2 argument transform: rl.Matrix - transform from the mesh's space to world space
+func (mesh *MeshCollider) InitFromMesh(mesh_data rl.Mesh, transform rl.Matrix) {
+	mesh.Init(appendMeshTriangles(nil, mesh_data, transform))
+}
+
+// Initializes the collider from the triangles of every mesh of a loaded model
+//
+// #1 argument model: rl.Model - the model, model.Transform is used for placing it in the world
+func (mesh *MeshCollider) InitFromModel(model rl.Model) {
+	triangles := [][3]rl.Vector3{}
+	for _, mesh_data := range model.GetMeshes() {
+		triangles = appendMeshTriangles(triangles, mesh_data, model.Transform)
+	}
+
+	mesh.Init(triangles)
+}
+
+// Gets the smallest axis-aligned box around the mesh
+//
+// #1 return: rl.BoundingBox - the box
+func (mesh *MeshCollider) GetBoundingBox() rl.BoundingBox {
+	return mesh.bounding_box
+}
+
+// Checks if an axis-aligned box collides with any triangle of the mesh
+//
+// #1 argument box: rl.BoundingBox - the axis-aligned box
+//
+// #1 return: bool - true if the box collides with the mesh
+func (mesh *MeshCollider) CheckCollisionBox(box rl.BoundingBox) bool {
+	for _, i := range mesh.queryTriangles(box) {
+		if checkCollisionBoxTriangle(box, mesh.Triangles[i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Finds when a moving axis-aligned box hits the first triangle of the mesh, triangles the box is already inside are ignored
+//
+// #1 argument box: rl.BoundingBox - the moving box
+//
+// #2 argument offset: rl.Vector3 - how far the box moves
+//
+// #1 return: float32 - part of the offset the box moves before the hit, from 0 to 1
+//
+// #2 return: rl.Vector3 - normal of the hit surface
+//
+// #3 return: bool - false if the box doesn't hit the mesh
+func (mesh *MeshCollider) SweepBox(box rl.BoundingBox, offset rl.Vector3) (float32, rl.Vector3, bool) {
+	area := rl.BoundingBox{
+		Min: rl.Vector3Min(box.Min, rl.Vector3Add(box.Min, offset)),
+		Max: rl.Vector3Max(box.Max, rl.Vector3Add(box.Max, offset)),
+	}
+
+	time := float32(1.)
+	normal := rl.Vector3{X: 0., Y: 0., Z: 0.}
+	is_hit := false
+	for _, i := range mesh.queryTriangles(area) {
+		if triangle_time, triangle_normal, ok := sweepBoxTriangle(box, offset, mesh.Triangles[i]); ok && triangle_time < time {
+			time, normal, is_hit = triangle_time, triangle_normal, true
+		}
+	}
+
+	return time, normal, is_hit
+}
+
+// Finds where a ray hits the mesh
+//
+// #1 argument ray: rl.Ray - the ray
+//
+// #1 return: rl.RayCollision - the closest collision in front of the ray
+func (mesh *MeshCollider) GetRayCollision(ray rl.Ray) rl.RayCollision {
+	closest := rl.RayCollision{Hit: false, Distance: 0., Point: rl.Vector3{X: 0., Y: 0., Z: 0.}, Normal: rl.Vector3{X: 0., Y: 0., Z: 0.}}
+	if len(mesh.Triangles) == 0 || !rl.GetRayCollisionBox(ray, mesh.bounding_box).Hit {
+		return closest
+	}
+
+	for _, triangle := range mesh.Triangles {
+		collision := rl.GetRayCollisionTriangle(ray, triangle[0], triangle[1], triangle[2])
+		if collision.Hit && (!closest.Hit || collision.Distance < closest.Distance) {
+			closest = collision
+		}
+	}
+
+	return closest
+}
+
+// Finds the triangles in the cells overlapped by an area
+//
+// #1 argument box: rl.BoundingBox - the area
+//
+// #1 return: []int - indexes of mesh.Triangles, valid until the next query
+func (mesh *MeshCollider) queryTriangles(box rl.BoundingBox) []int {
+	if !mesh.grid.isInitialized() || mesh.grid.count != len(mesh.Triangles) {
+		mesh.Init(mesh.Triangles)
+	}
+	if !rl.CheckCollisionBoxes(box, mesh.bounding_box) {
+		return mesh.query[:0]
+	}
+
+	mesh.query = mesh.grid.query(box, nil, mesh.query[:0])
+
+	return mesh.query
+}
+
+// Appends the triangles of a raylib mesh
+//
+// #1 argument triangles: [][3]rl.Vector3 - slice where the triangles are appended
+//
+// #2 argument mesh_data: rl.Mesh - the mesh
+//
+// #3 argument transform: rl.Matrix - transform from the mesh's space to world space
+//
+// #1 return: [][3]rl.Vector3 - the slice with the new triangles
+func appendMeshTriangles(triangles [][3]rl.Vector3, mesh_data rl.Mesh, transform rl.Matrix) [][3]rl.Vector3 {
+	if mesh_data.Vertices == nil || mesh_data.VertexCount <= 0 {
+		return triangles
+	}
+
+	vertices := unsafe.Slice(mesh_data.Vertices, mesh_data.VertexCount*3)
+	var indices []uint16
+	if mesh_data.Indices != nil {
+		indices = unsafe.Slice(mesh_data.Indices, mesh_data.TriangleCount*3)
+	}
+
+	get_vertex := func(i int) rl.Vector3 {
+		if indices != nil {
+			i = int(indices[i])
+		}
+		return rl.Vector3Transform(rl.Vector3{X: vertices[i*3], Y: vertices[i*3+1], Z: vertices[i*3+2]}, transform)
+	}
+
+	// Meshes without indices store every triangle as three vertices after each other
+	count := int(mesh_data.TriangleCount)
+	if indices == nil {
+		count = int(mesh_data.VertexCount) / 3
+	}
+	for i := 0; i < count; i++ {
+		triangles = append(triangles, [3]rl.Vector3{get_vertex(i * 3), get_vertex(i*3 + 1), get_vertex(i*3 + 2)})
+	}
+
+	return triangles
+}
+
+// Gets the smallest axis-aligned box around a triangle
+//
+// #1 argument triangle: [3]rl.Vector3 - the triangle
+//
+// #1 return: rl.BoundingBox - the box
+func getTriangleBoundingBox(triangle [3]rl.Vector3) rl.BoundingBox {
+	return rl.BoundingBox{
+		Min: rl.Vector3Min(triangle[0], rl.Vector3Min(triangle[1], triangle[2])),
+		Max: rl.Vector3Max(triangle[0], rl.Vector3Max(triangle[1], triangle[2])),
+	}
+}
+
+// Checks if an axis-aligned box collides with a triangle, touching counts as a collision
+//
+// #1 argument box: rl.BoundingBox - the box
+//
+// #2 argument triangle: [3]rl.Vector3 - the triangle
+//
+// #1 return: bool - true if the box collides with the triangle
+func checkCollisionBoxTriangle(box rl.BoundingBox, triangle [3]rl.Vector3) bool {
+	axes := getTriangleSeparatingAxes(triangle)
+
+	return checkCollisionBoxConvex(box, triangle[:], axes[:])
+}
+
+// Finds when a moving axis-aligned box hits a triangle
+//
+// #1 argument box: rl.BoundingBox - the moving box
+//
+// #2 argument offset: rl.Vector3 - how far the box moves
+//
+// #3 argument triangle: [3]rl.Vector3 - the triangle
+//
+// #1 return: float32 - part of the offset the box moves before the hit, from 0 to 1
+//
+// #2 return: rl.Vector3 - normal of the hit surface, pointing towards the box
+//
+// #3 return: bool - false if the box doesn't hit the triangle or is already inside it
+func sweepBoxTriangle(box rl.BoundingBox, offset rl.Vector3, triangle [3]rl.Vector3) (float32, rl.Vector3, bool) {
+	axes := getTriangleSeparatingAxes(triangle)
+
+	return sweepBoxConvex(box, offset, triangle[:], axes[:])
+}
+
+// Checks if an axis-aligned box collides with a convex shape, touching counts as a collision
+//
+// #1 argument box: rl.BoundingBox - the box
+//
+// #2 argument vertices: []rl.Vector3 - corners of the convex shape
+//
+// #3 argument axes: []rl.Vector3 - axes which can separate the box and the shape, zero vectors are skipped
+//
+// #1 return: bool - true if the box collides with the shape
+func checkCollisionBoxConvex(box rl.BoundingBox, vertices []rl.Vector3, axes []rl.Vector3) bool {
+	center := rl.Vector3Scale(rl.Vector3Add(box.Min, box.Max), .5)
+	half_extents := rl.Vector3Scale(rl.Vector3Subtract(box.Max, box.Min), .5)
+
+	// The box and the shape don't collide if they are apart on any of the separating axes
+	for _, axis := range axes {
+		if axis.X == 0. && axis.Y == 0. && axis.Z == 0. {
+			continue
+		}
+
+		shape_min, shape_max := getConvexProjection(axis, vertices)
+		box_center := rl.Vector3DotProduct(center, axis)
+		box_radius := math32.Abs(axis.X)*half_extents.X + math32.Abs(axis.Y)*half_extents.Y + math32.Abs(axis.Z)*half_extents.Z
+
+		if box_center-box_radius > shape_max || box_center+box_radius < shape_min {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Finds when a moving axis-aligned box hits a convex shape
+//
+// #1 argument box: rl.BoundingBox - the moving box
+//
+// #2 argument offset: rl.Vector3 - how far the box moves
+//
+// #3 argument vertices: []rl.Vector3 - corners of the convex shape
+//
+// #4 argument axes: []rl.Vector3 - axes which can separate the box and the shape, zero vectors are skipped
+//
+// #1 return: float32 - part of the offset the box moves before the hit, from 0 to 1
+//
+// #2 return: rl.Vector3 - normal of the hit surface, pointing towards the box
+//
+// #3 return: bool - false if the box doesn't hit the shape or is already inside it
+func sweepBoxConvex(box rl.BoundingBox, offset rl.Vector3, vertices []rl.Vector3, axes []rl.Vector3) (float32, rl.Vector3, bool) {
+	center := rl.Vector3Scale(rl.Vector3Add(box.Min, box.Max), .5)
+	half_extents := rl.Vector3Scale(rl.Vector3Subtract(box.Max, box.Min), .5)
+
+	entry := math32.Inf(-1)
+	exit := math32.Inf(1)
+	normal := rl.Vector3{X: 0., Y: 0., Z: 0.}
+
+	// The box and the shape collide while their projections overlap on every separating axis
+	for _, axis := range axes {
+		if axis.X == 0. && axis.Y == 0. && axis.Z == 0. {
+			continue
+		}
+
+		shape_min, shape_max := getConvexProjection(axis, vertices)
+		radius := math32.Abs(axis.X)*half_extents.X + math32.Abs(axis.Y)*half_extents.Y + math32.Abs(axis.Z)*half_extents.Z + (shape_max-shape_min)*.5
+		gap := (shape_max+shape_min)*.5 - rl.Vector3DotProduct(center, axis)
+		speed := rl.Vector3DotProduct(offset, axis)
+
+		if math32.Abs(speed) < 1e-7 {
+			if math32.Abs(gap) >= radius {
+				return 1., rl.Vector3{X: 0., Y: 0., Z: 0.}, false
+			}
+			continue
+		}
+
+		enter := (gap - radius) / speed
+		leave := (gap + radius) / speed
+		if enter > leave {
+			enter, leave = leave, enter
+		}
+
+		if enter > entry {
+			entry = enter
+			normal = rl.Vector3Scale(axis, -math32.Copysign(1., speed))
+		}
+		exit = math32.Min(exit, leave)
+	}
+
+	if entry >= exit || entry < 0. || entry > 1. {
+		return 1., rl.Vector3{X: 0., Y: 0., Z: 0.}, false
+	}
+
+	return entry, normal, true
+}
+
+// Gets the axes which can separate an axis-aligned box and a triangle
+//
+// #1 argument triangle: [3]rl.Vector3 - the triangle
+//
+// #1 return: [13]rl.Vector3 - the world axes, the normal of the triangle and the cross products of the world axes with its edges,
+// degenerate axes are left as zero vectors and have to be skipped
+func getTriangleSeparatingAxes(triangle [3]rl.Vector3) [13]rl.Vector3 {
+	world_axes := [3]rl.Vector3{{X: 1., Y: 0., Z: 0.}, {X: 0., Y: 1., Z: 0.}, {X: 0., Y: 0., Z: 1.}}
+	edges := [3]rl.Vector3{
+		rl.Vector3Subtract(triangle[1], triangle[0]),
+		rl.Vector3Subtract(triangle[2], triangle[1]),
+		rl.Vector3Subtract(triangle[0], triangle[2]),
+	}
+
+	axes := [13]rl.Vector3{}
+	copy(axes[:], world_axes[:])
+	axes[3] = getNormalizedAxis(rl.Vector3CrossProduct(edges[0], edges[1]))
+	for i, world_axis := range world_axes {
+		for j, edge := range edges {
+			axes[4+i*3+j] = getNormalizedAxis(rl.Vector3CrossProduct(world_axis, edge))
+		}
+	}
+
+	return axes
+}
+
+// Normalizes an axis, too short axes are replaced by a zero vector
+//
+// #1 argument axis: rl.Vector3 - the axis
+//
+// #1 return: rl.Vector3 - the normalized axis
+func getNormalizedAxis(axis rl.Vector3) rl.Vector3 {
+	length := rl.Vector3Length(axis)
+	if length < 1e-6 {
+		return rl.Vector3{X: 0., Y: 0., Z: 0.}
+	}
+
+	return rl.Vector3Scale(axis, 1./length)
+}
+
+// Projects a convex shape on an axis
+//
+// #1 argument axis: rl.Vector3 - the axis
+//
+// #2 argument vertices: []rl.Vector3 - corners of the shape
+//
+// #1 return: float32 - the lowest projected corner
+//
+// #2 return: float32 - the highest projected corner
+func getConvexProjection(axis rl.Vector3, vertices []rl.Vector3) (float32, float32) {
+	lowest := math32.Inf(1)
+	highest := math32.Inf(-1)
+	for _, vertex := range vertices {
+		projected := rl.Vector3DotProduct(vertex, axis)
+		lowest = math32.Min(lowest, projected)
+		highest = math32.Max(highest, projected)
+	}
+
+	return lowest, highest
+}
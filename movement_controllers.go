@@ -0,0 +1,230 @@
+package rlfp
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Used for moving the player, player.Movement can be switched at any time to change how the player moves
+type MovementController interface {
+	// Updates the player's rotation, velocity and position, called once per frame by world.StepPlayer
+	//
+	// #1 argument world: *World - the world with the player
+	//
+	// #2 argument mouse_delta: rl.Vector2 - mouse movement since the last frame
+	Update(world *World, mouse_delta rl.Vector2)
+	// Checks if the player collides with the world, kinematic and dynamic boxes don't push or carry the player otherwise
+	//
+	// #1 return: bool - true if the player collides with the world
+	HasCollisions() bool
+	// Checks if the player can activate trigger boxes and interact with interactable boxes
+	//
+	// #1 return: bool - true if the player can activate trigger boxes and interact with interactable boxes
+	HasInteractions() bool
+}
+
+// Names of the built-in movement controllers, used for saving player.Movement
+const (
+	MovementWalk      = "walk"
+	MovementQuake     = "quake"
+	MovementFly       = "fly"
+	MovementNoclip    = "noclip"
+	MovementSpectator = "spectator"
+)
+
+// Walks on the ground with gravity, jumping and crouching, the player accelerates by player.Speed
+type WalkController struct{}
+
+// Updates the player's rotation, velocity and position by walking
+//
+// #1 argument world: *World - the world with the player
+//
+// #2 argument mouse_delta: rl.Vector2 - mouse movement since the last frame
+func (controller *WalkController) Update(world *World, mouse_delta rl.Vector2) {
+	// Update variables that don't affect player's current position
+	world.UpdatePlayerVariables()
+	// Updates player's position and states
+	world.Player.Rotate(mouse_delta)
+	world.UpdatePlayerCrouch()
+	world.UpdatePlayerPosition()
+}
+
+// The walking player collides with the world
+//
+// #1 return: bool - always true
+func (controller *WalkController) HasCollisions() bool {
+	return true
+}
+
+// The walking player activates trigger boxes and interacts with interactable boxes
+//
+// #1 return: bool - always true
+func (controller *WalkController) HasInteractions() bool {
+	return true
+}
+
+// Walks like WalkController with the classic Quake/Source movement with strafe-jumping and bunny-hopping, see player.Quake
+// Without player.Quake.AutoHop the jump key has to be pressed again for every jump
+type QuakeController struct {
+	WalkController
+}
+
+// Updates the player's rotation, velocity and position by walking with the Quake/Source movement
+//
+// #1 argument world: *World - the world with the player
+//
+// #2 argument mouse_delta: rl.Vector2 - mouse movement since the last frame
+func (controller *QuakeController) Update(world *World, mouse_delta rl.Vector2) {
+	// Update variables that don't affect player's current position
+	world.Player.UpdateLastDirectionalKeyPressed()
+	world.UpdatePlayerJumpTimers()
+	world.UpdatePlayerQuakeVelocity()
+	// Updates player's position and states
+	world.Player.Rotate(mouse_delta)
+	world.UpdatePlayerCrouch()
+	world.UpdatePlayerPosition()
+}
+
+// Flies without gravity and still collides with the world, the jump key moves the player up and the crouch key down
+type FlyController struct{}
+
+// Updates the player's rotation, velocity and position by flying
+//
+// #1 argument world: *World - the world with the player
+//
+// #2 argument mouse_delta: rl.Vector2 - mouse movement since the last frame
+func (controller *FlyController) Update(world *World, mouse_delta rl.Vector2) {
+	world.Player.Rotate(mouse_delta)
+	world.UpdatePlayerFlyingVelocity()
+	world.UpdatePlayerOffsetNextFrame()
+	world.MovePlayer(world.Player.OffsetNextFrame)
+}
+
+// The flying player collides with the world
+//
+// #1 return: bool - always true
+func (controller *FlyController) HasCollisions() bool {
+	return true
+}
+
+// The flying player activates trigger boxes and interacts with interactable boxes
+//
+// #1 return: bool - always true
+func (controller *FlyController) HasInteractions() bool {
+	return true
+}
+
+// Flies like FlyController through bounding boxes, colliders and the ground
+type NoclipController struct{}
+
+// Updates the player's rotation, velocity and position by flying through everything
+//
+// #1 argument world: *World - the world with the player
+//
+// #2 argument mouse_delta: rl.Vector2 - mouse movement since the last frame
+func (controller *NoclipController) Update(world *World, mouse_delta rl.Vector2) {
+	world.Player.Rotate(mouse_delta)
+	world.UpdatePlayerFlyingVelocity()
+	world.UpdatePlayerOffsetNextFrame()
+	world.setPlayerBoundingBox(moveBox(world.Player.BoundingBox, world.Player.OffsetNextFrame))
+}
+
+// The player in noclip doesn't collide with the world
+//
+// #1 return: bool - always false
+func (controller *NoclipController) HasCollisions() bool {
+	return false
+}
+
+// The player in noclip still activates trigger boxes and interacts with interactable boxes
+//
+// #1 return: bool - always true
+func (controller *NoclipController) HasInteractions() bool {
+	return true
+}
+
+// Flies like NoclipController, but without activating trigger boxes and interacting with interactable boxes
+type SpectatorController struct {
+	NoclipController
+}
+
+// The spectating player doesn't activate trigger boxes and doesn't interact with interactable boxes
+//
+// #1 return: bool - always false
+func (controller *SpectatorController) HasInteractions() bool {
+	return false
+}
+
+// Gets the name of a built-in movement controller
+//
+// #1 argument controller: MovementController - the controller
+//
+// #1 return: string - MovementWalk, MovementQuake, MovementFly, MovementNoclip or MovementSpectator, empty for other controllers
+func getMovementControllerName(controller MovementController) string {
+	switch controller.(type) {
+	case *WalkController:
+		return MovementWalk
+	case *QuakeController:
+		return MovementQuake
+	case *FlyController:
+		return MovementFly
+	case *NoclipController:
+		return MovementNoclip
+	case *SpectatorController:
+		return MovementSpectator
+	}
+
+	return ""
+}
+
+// Creates a built-in movement controller by its name
+//
+// #1 argument name: string - MovementWalk, MovementQuake, MovementFly, MovementNoclip or MovementSpectator
+//
+// #1 return: MovementController - the new controller, nil for unknown names
+func newMovementController(name string) MovementController {
+	switch name {
+	case MovementWalk:
+		return &WalkController{}
+	case MovementQuake:
+		return &QuakeController{}
+	case MovementFly:
+		return &FlyController{}
+	case MovementNoclip:
+		return &NoclipController{}
+	case MovementSpectator:
+		return &SpectatorController{}
+	}
+
+	return nil
+}
+
+// Updates player's velocity when flying, the velocity accelerates towards the held direction and slows down when nothing is held
+// The jump key moves the player up and the crouch key down, sprinting speeds the player up
+func (world *World) UpdatePlayerFlyingVelocity() {
+	direction := world.Player.GetWishDirection()
+	wish := rl.Vector3{X: direction.X, Y: 0., Z: direction.Y}
+	if world.Player.CurrentInputs[ControlJump] {
+		wish.Y += 1.
+	}
+	if world.Player.CurrentInputs[ControlCrouch] {
+		wish.Y -= 1.
+	}
+
+	max_speed := world.Player.Speed.Normal
+	if world.Player.CurrentInputs[ControlSprint] {
+		max_speed = world.Player.Speed.Sprint
+	}
+
+	velocity := rl.Vector3{X: world.Player.HorizontalVelocity.X, Y: world.Player.YVelocity, Z: world.Player.HorizontalVelocity.Y}
+	if rl.Vector3Length(wish) > 1e-6 {
+		// Accelerate towards the held direction
+		velocity = moveVector3Towards(velocity, rl.Vector3Scale(rl.Vector3Normalize(wish), max_speed), world.Player.Speed.Acceleration*world.FrameTime)
+	} else {
+		// Slow down to zero when nothing is held
+		velocity = moveVector3Towards(velocity, rl.Vector3{X: 0., Y: 0., Z: 0.}, world.Player.Speed.Friction*world.FrameTime)
+	}
+
+	world.Player.HorizontalVelocity = rl.Vector2{X: velocity.X, Y: velocity.Z}
+	world.Player.YVelocity = velocity.Y
+	world.Player.Speed.Current = rl.Vector2Length(world.Player.HorizontalVelocity)
+}
@@ -0,0 +1,119 @@
+package rlfp
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Movement controller which isn't built-in, it walks like WalkController
+type testController struct {
+	WalkController
+}
+
+// Creates a world with a ceiling at Y 4 and a trigger box around the player
+//
+// #1 return: *World - the new world
+func newMovementTestWorld() *World {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: -5., Y: 4., Z: -5.}, Max: rl.Vector3{X: 5., Y: 5., Z: 5.}})
+	world.AddTriggerBox(rl.BoundingBox{Min: rl.Vector3{X: -1., Y: 0., Z: -1.}, Max: rl.Vector3{X: 1., Y: 3., Z: 1.}})
+	stepTestWorld(world, 5, [ControlCount]bool{})
+	world.PollEvents()
+
+	return world
+}
+
+// A flying player goes up until the ceiling and hovers when nothing is held
+func TestFlyController(t *testing.T) {
+	world := newMovementTestWorld()
+	world.Player.Movement = &FlyController{}
+
+	stepTestWorld(world, 120, getTestInputs(ControlJump))
+	if world.Player.BoundingBox.Max.Y < 3.99 || world.Player.BoundingBox.Max.Y > 4. {
+		t.Errorf("player flew to Y %g, want stopped at the ceiling at Y 4", world.Player.BoundingBox.Max.Y)
+	}
+
+	stepTestWorld(world, 60, getTestInputs(ControlCrouch))
+	y := world.Player.BoundingBox.Min.Y
+	stepTestWorld(world, 60, [ControlCount]bool{})
+	if world.Player.BoundingBox.Min.Y != y || y <= 0. {
+		t.Errorf("player moved from Y %g to Y %g without holding a key, want hovering", y, world.Player.BoundingBox.Min.Y)
+	}
+}
+
+// A noclip player goes through the ceiling and still triggers boxes
+func TestNoclipController(t *testing.T) {
+	world := newMovementTestWorld()
+	world.Player.Movement = &NoclipController{}
+
+	stepTestWorld(world, 120, getTestInputs(ControlJump))
+	if world.Player.BoundingBox.Min.Y < 5. {
+		t.Errorf("player got to Y %g, want through the ceiling", world.Player.BoundingBox.Min.Y)
+	}
+
+	events := world.PollEvents()
+	if len(events) == 0 || events[len(events)-1].Type != EventTriggerExit {
+		t.Error("player didn't leave the trigger box")
+	}
+}
+
+// A spectator doesn't trigger boxes and the walking player falls back to the ground
+func TestSpectatorController(t *testing.T) {
+	world := newMovementTestWorld()
+	world.Player.Movement = &SpectatorController{}
+
+	stepTestWorld(world, 1, [ControlCount]bool{})
+	events := world.PollEvents()
+	if len(events) != 1 || events[0].Type != EventTriggerExit {
+		t.Errorf("spectator got events %v, want only leaving the trigger box", events)
+	}
+	stepTestWorld(world, 60, getTestInputs(ControlJump))
+	if len(world.PollEvents()) != 0 {
+		t.Error("spectator triggered a box")
+	}
+
+	world.Player.Movement = &WalkController{}
+	stepTestWorld(world, 120, [ControlCount]bool{})
+	if world.Player.BoundingBox.Min.Y > .01 {
+		t.Errorf("walking player is at Y %g, want on the ground", world.Player.BoundingBox.Min.Y)
+	}
+}
+
+// A player without a movement controller walks and the boxes that check the controller don't panic
+func TestNilMovementController(t *testing.T) {
+	world := newMovementTestWorld()
+	world.AddRigidBox(rl.BoundingBox{Min: rl.Vector3{X: 2., Y: 1., Z: -1.}, Max: rl.Vector3{X: 3., Y: 2., Z: 1.}}, 1.)
+	world.SetBoundingBoxVelocity(world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: 2., Y: 0., Z: 3.}, Max: rl.Vector3{X: 3., Y: 1., Z: 4.}}), rl.Vector3{X: 0., Y: 0., Z: .5})
+	world.AddInteractableBox(rl.BoundingBox{Min: rl.Vector3{X: -3., Y: 0., Z: -1.}, Max: rl.Vector3{X: -2., Y: 2., Z: 1.}})
+	world.Player.Movement = nil
+
+	stepTestWorld(world, 60, getTestInputs(ControlForward, ControlInteract))
+	if world.Player.Position.X > -.5 {
+		t.Errorf("player without a movement controller walked to X %g, want walking forward", world.Player.Position.X)
+	}
+}
+
+// Built-in movement controllers are restored from the state by their name and other controllers are kept
+func TestMovementControllerState(t *testing.T) {
+	world := newMovementTestWorld()
+	world.Player.Movement = &FlyController{}
+	state := world.GetState()
+	if state.Player.Movement != MovementFly {
+		t.Fatalf("saved movement controller %q, want %q", state.Player.Movement, MovementFly)
+	}
+
+	world.Player.Movement = &WalkController{}
+	world.SetState(state)
+	if _, ok := world.Player.Movement.(*FlyController); !ok {
+		t.Errorf("restored movement controller %T, want *FlyController", world.Player.Movement)
+	}
+
+	custom := &testController{}
+	world.Player.Movement = custom
+	state = world.GetState()
+	world.SetState(state)
+	if state.Player.Movement != "" || world.Player.Movement != custom {
+		t.Errorf("controller which isn't built-in was saved as %q and restored as %T", state.Player.Movement, world.Player.Movement)
+	}
+}
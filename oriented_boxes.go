@@ -0,0 +1,343 @@
+package rlfp
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"github.com/chewxy/math32"
+)
+
+// Box that can be rotated, e.g. a rotated wall or crate
+type OrientedBox struct {
+	// Center of the box
+	Center rl.Vector3
+	// Half of the size of the box on its own axes
+	HalfExtents rl.Vector3
+	// Rotation of the box, has to be normalized (rl.QuaternionFromEuler, rl.QuaternionFromAxisAngle)
+	Rotation rl.Quaternion
+}
+
+// Gets the axes of the box after the rotation
+//
+// #1 return: [3]rl.Vector3 - the X, Y and Z axis of the box
+func (box OrientedBox) GetAxes() [3]rl.Vector3 {
+	return [3]rl.Vector3{
+		rl.Vector3RotateByQuaternion(rl.Vector3{X: 1., Y: 0., Z: 0.}, box.Rotation),
+		rl.Vector3RotateByQuaternion(rl.Vector3{X: 0., Y: 1., Z: 0.}, box.Rotation),
+		rl.Vector3RotateByQuaternion(rl.Vector3{X: 0., Y: 0., Z: 1.}, box.Rotation),
+	}
+}
+
+// Gets the smallest axis-aligned box around the box, used for finding nearby boxes
+//
+// #1 return: rl.BoundingBox - the axis-aligned box
+func (box OrientedBox) GetBoundingBox() rl.BoundingBox {
+	axes := box.GetAxes()
+	extents := rl.Vector3{X: 0., Y: 0., Z: 0.}
+	for i, extent := range [3]float32{box.HalfExtents.X, box.HalfExtents.Y, box.HalfExtents.Z} {
+		extents.X += math32.Abs(axes[i].X) * extent
+		extents.Y += math32.Abs(axes[i].Y) * extent
+		extents.Z += math32.Abs(axes[i].Z) * extent
+	}
+
+	return rl.BoundingBox{
+		Min: rl.Vector3Subtract(box.Center, extents),
+		Max: rl.Vector3Add(box.Center, extents),
+	}
+}
+
+// Gets the corners of the box
+//
+// #1 return: [8]rl.Vector3 - the corners, the index's bits select the positive side of the X, Y and Z axis
+func (box OrientedBox) GetCorners() [8]rl.Vector3 {
+	axes := box.GetAxes()
+	corners := [8]rl.Vector3{}
+	for i := range corners {
+		corner := box.Center
+		for axis, extent := range [3]float32{box.HalfExtents.X, box.HalfExtents.Y, box.HalfExtents.Z} {
+			if i&(1<<axis) == 0 {
+				extent = -extent
+			}
+			corner = rl.Vector3Add(corner, rl.Vector3Scale(axes[axis], extent))
+		}
+		corners[i] = corner
+	}
+
+	return corners
+}
+
+// Gets the box moved by an offset
+//
+// #1 argument offset: rl.Vector3 - the offset
+//
+// #1 return: OrientedBox - the moved box
+func (box OrientedBox) Move(offset rl.Vector3) OrientedBox {
+	box.Center = rl.Vector3Add(box.Center, offset)
+
+	return box
+}
+
+// Gets the box with a little extra space, used for drawing
+//
+// #1 return: OrientedBox - the bigger box
+func (box OrientedBox) getBoxOver() OrientedBox {
+	box.HalfExtents = rl.Vector3AddValue(box.HalfExtents, .02)
+
+	return box
+}
+
+// Draws the edges of an oriented box
+//
+// #1 argument box: OrientedBox - the box
+//
+// #2 argument color: rl.Color - color of the edges
+func DrawOrientedBox(box OrientedBox, color rl.Color) {
+	corners := box.GetCorners()
+	for i := range corners {
+		for axis := 0; axis < 3; axis++ {
+			// Every edge is drawn once, from the corner on the negative side of the axis
+			if i&(1<<axis) == 0 {
+				rl.DrawLine3D(corners[i], corners[i|1<<axis], color)
+			}
+		}
+	}
+}
+
+// Finds where a ray hits an oriented box
+//
+// #1 argument ray: rl.Ray - the ray
+//
+// #2 argument box: OrientedBox - the box
+//
+// #1 return: rl.RayCollision - the collision, the same as rl.GetRayCollisionBox returns for axis-aligned boxes
+func GetRayCollisionOrientedBox(ray rl.Ray, box OrientedBox) rl.RayCollision {
+	// Hit the axis-aligned box in the box's own space and rotate the result back
+	inverse := rl.QuaternionInvert(box.Rotation)
+	local_ray := rl.Ray{
+		Position:  rl.Vector3RotateByQuaternion(rl.Vector3Subtract(ray.Position, box.Center), inverse),
+		Direction: rl.Vector3RotateByQuaternion(ray.Direction, inverse),
+	}
+
+	collision := rl.GetRayCollisionBox(local_ray, rl.BoundingBox{Min: rl.Vector3Negate(box.HalfExtents), Max: box.HalfExtents})
+	if collision.Hit {
+		collision.Point = rl.Vector3Add(rl.Vector3RotateByQuaternion(collision.Point, box.Rotation), box.Center)
+		collision.Normal = rl.Vector3RotateByQuaternion(collision.Normal, box.Rotation)
+	}
+
+	return collision
+}
+
+// Checks if an axis-aligned box collides with an oriented box, touching boxes collide
+//
+// #1 argument box: rl.BoundingBox - the axis-aligned box
+//
+// #2 argument oriented: OrientedBox - the oriented box
+//
+// #1 return: bool - true if the boxes collide
+func CheckCollisionBoxOrientedBox(box rl.BoundingBox, oriented OrientedBox) bool {
+	center := rl.Vector3Scale(rl.Vector3Add(box.Min, box.Max), .5)
+	half_extents := rl.Vector3Scale(rl.Vector3Subtract(box.Max, box.Min), .5)
+	distance := rl.Vector3Subtract(oriented.Center, center)
+
+	// The boxes don't collide if they are apart on any of the separating axes
+	for _, axis := range getSeparatingAxes(oriented) {
+		box_radius, oriented_radius := getProjectedRadii(axis, half_extents, oriented)
+		if math32.Abs(rl.Vector3DotProduct(distance, axis)) > box_radius+oriented_radius {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Finds when a moving axis-aligned box hits an oriented box
+//
+// #1 argument box: rl.BoundingBox - the moving box
+//
+// #2 argument offset: rl.Vector3 - how far the box moves
+//
+// #3 argument oriented: OrientedBox - the box that can be hit
+//
+// #1 return: float32 - part of the offset the box moves before the hit, from 0 to 1
+//
+// #2 return: rl.Vector3 - normal of the hit surface
+//
+// #3 return: bool - false if the box doesn't hit the oriented box or is already inside it
+func SweepBoundingBoxOrientedBox(box rl.BoundingBox, offset rl.Vector3, oriented OrientedBox) (float32, rl.Vector3, bool) {
+	corners := oriented.GetCorners()
+
+	return sweepBoxConvex(box, offset, corners[:], getSeparatingAxes(oriented))
+}
+
+// Gets the axes that can separate an axis-aligned box and an oriented box
+//
+// #1 argument oriented: OrientedBox - the oriented box
+//
+// #1 return: []rl.Vector3 - the world axes, the oriented box's axes and their cross products, all normalized
+func getSeparatingAxes(oriented OrientedBox) []rl.Vector3 {
+	world_axes := [3]rl.Vector3{{X: 1., Y: 0., Z: 0.}, {X: 0., Y: 1., Z: 0.}, {X: 0., Y: 0., Z: 1.}}
+	box_axes := oriented.GetAxes()
+
+	axes := make([]rl.Vector3, 0, 15)
+	axes = append(axes, world_axes[:]...)
+	axes = append(axes, box_axes[:]...)
+	for _, world_axis := range world_axes {
+		for _, box_axis := range box_axes {
+			// Parallel axes don't give a new axis
+			cross := rl.Vector3CrossProduct(world_axis, box_axis)
+			if length := rl.Vector3Length(cross); length > 1e-4 {
+				axes = append(axes, rl.Vector3Scale(cross, 1./length))
+			}
+		}
+	}
+
+	return axes
+}
+
+// Gets how far an axis-aligned box and an oriented box reach from their centers along an axis
+//
+// #1 argument axis: rl.Vector3 - the axis, normalized
+//
+// #2 argument half_extents: rl.Vector3 - half of the size of the axis-aligned box
+//
+// #3 argument oriented: OrientedBox - the oriented box
+//
+// #1 return: float32 - the reach of the axis-aligned box
+//
+// #2 return: float32 - the reach of the oriented box
+func getProjectedRadii(axis rl.Vector3, half_extents rl.Vector3, oriented OrientedBox) (float32, float32) {
+	box_radius := math32.Abs(axis.X)*half_extents.X + math32.Abs(axis.Y)*half_extents.Y + math32.Abs(axis.Z)*half_extents.Z
+
+	box_axes := oriented.GetAxes()
+	oriented_radius := math32.Abs(rl.Vector3DotProduct(axis, box_axes[0]))*oriented.HalfExtents.X +
+		math32.Abs(rl.Vector3DotProduct(axis, box_axes[1]))*oriented.HalfExtents.Y +
+		math32.Abs(rl.Vector3DotProduct(axis, box_axes[2]))*oriented.HalfExtents.Z
+
+	return box_radius, oriented_radius
+}
+
+// Adds a new oriented bounding box to the world, world.BoundingBoxes gets the axis-aligned box around it
+// Dynamic and pushable boxes always collide as axis-aligned boxes
+//
+// #1 argument box: OrientedBox - the box to add
+//
+// #1 return: BoxID - stable ID of the new box
+func (world *World) AddOrientedBoundingBox(box OrientedBox) BoxID {
+	id := world.AddBoundingBox(box.GetBoundingBox())
+	world.BoundingBoxes[len(world.BoundingBoxes)-1].Oriented = box
+	world.BoundingBoxes[len(world.BoundingBoxes)-1].IsOriented = true
+
+	return id
+}
+
+// Moves, resizes or rotates an oriented bounding box
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #2 argument box: OrientedBox - the new box
+//
+// #1 return: bool - false if there is no bounding box with the ID
+func (world *World) SetOrientedBoundingBox(id BoxID, box OrientedBox) bool {
+	if !world.SetBoundingBoxBounds(id, box.GetBoundingBox()) {
+		return false
+	}
+
+	i := world.getBoxIndex(&world.bounding_box_indexes, len(world.BoundingBoxes), id)
+	world.BoundingBoxes[i].Oriented = box
+	world.BoundingBoxes[i].IsOriented = true
+
+	return true
+}
+
+// Adds a new oriented trigger box to the world, world.TriggerBoxes gets the axis-aligned box around it
+//
+// #1 argument box: OrientedBox - the box that triggers the event
+//
+// #1 return: BoxID - stable ID of the new box
+func (world *World) AddOrientedTriggerBox(box OrientedBox) BoxID {
+	id := world.AddTriggerBox(box.GetBoundingBox())
+	world.TriggerBoxes[len(world.TriggerBoxes)-1].Oriented = box
+	world.TriggerBoxes[len(world.TriggerBoxes)-1].IsOriented = true
+
+	return id
+}
+
+// Moves, resizes or rotates an oriented trigger box
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #2 argument box: OrientedBox - the new box
+//
+// #1 return: bool - false if there is no trigger box with the ID
+func (world *World) SetOrientedTriggerBox(id BoxID, box OrientedBox) bool {
+	if !world.SetTriggerBoxBounds(id, box.GetBoundingBox()) {
+		return false
+	}
+
+	i := world.getBoxIndex(&world.trigger_box_indexes, len(world.TriggerBoxes), id)
+	world.TriggerBoxes[i].Oriented = box
+	world.TriggerBoxes[i].IsOriented = true
+
+	return true
+}
+
+// Adds a new oriented interactable box to the world, world.InteractableBoxes gets the axis-aligned box around it
+//
+// #1 argument box: OrientedBox - the box of the interactable object
+//
+// #1 return: BoxID - stable ID of the new box
+func (world *World) AddOrientedInteractableBox(box OrientedBox) BoxID {
+	id := world.AddInteractableBox(box.GetBoundingBox())
+	world.InteractableBoxes[len(world.InteractableBoxes)-1].Oriented = box
+	world.InteractableBoxes[len(world.InteractableBoxes)-1].IsOriented = true
+
+	return id
+}
+
+// Moves, resizes or rotates an oriented interactable box
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #2 argument box: OrientedBox - the new box
+//
+// #1 return: bool - false if there is no interactable box with the ID
+func (world *World) SetOrientedInteractableBox(id BoxID, box OrientedBox) bool {
+	if !world.SetInteractableBoxBounds(id, box.GetBoundingBox()) {
+		return false
+	}
+
+	i := world.getBoxIndex(&world.interactable_box_indexes, len(world.InteractableBoxes), id)
+	world.InteractableBoxes[i].Oriented = box
+	world.InteractableBoxes[i].IsOriented = true
+
+	return true
+}
+
+// Checks if an area collides with the shape of a bounding box
+//
+// #1 argument i: int - index of the bounding box
+//
+// #2 argument area: rl.BoundingBox - the area
+//
+// #1 return: bool - true if the area collides with the oriented box if the box has one, otherwise with its bounding box
+func (world *World) isBoundingBoxColliding(i int, area rl.BoundingBox) bool {
+	if world.BoundingBoxes[i].IsOriented {
+		return rl.CheckCollisionBoxes(area, world.BoundingBoxes[i].BoundingBox) && CheckCollisionBoxOrientedBox(area, world.BoundingBoxes[i].Oriented)
+	}
+
+	return rl.CheckCollisionBoxes(area, world.BoundingBoxes[i].BoundingBox)
+}
+
+// Finds where a ray hits the shape of a bounding box
+//
+// #1 argument ray: rl.Ray - the ray
+//
+// #2 argument i: int - index of the bounding box
+//
+// #1 return: rl.RayCollision - the collision with the oriented box if the box has one, otherwise with its bounding box
+func (world *World) getRayCollisionBoundingBox(ray rl.Ray, i int) rl.RayCollision {
+	if world.BoundingBoxes[i].IsOriented {
+		return GetRayCollisionOrientedBox(ray, world.BoundingBoxes[i].Oriented)
+	}
+
+	return rl.GetRayCollisionBox(ray, world.BoundingBoxes[i].BoundingBox)
+}
@@ -0,0 +1,62 @@
+package rlfp
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"github.com/chewxy/math32"
+)
+
+// Rotation by 45 degrees around the Y axis
+var testRotation = rl.QuaternionFromAxisAngle(rl.Vector3{X: 0., Y: 1., Z: 0.}, math32.Pi/4.)
+
+// Boxes collide with the rotated shape of an oriented box, not with its bounding box
+func TestCheckCollisionBoxOrientedBox(t *testing.T) {
+	oriented := OrientedBox{Center: rl.Vector3{X: 0., Y: 0., Z: 0.}, HalfExtents: rl.Vector3{X: 1., Y: 1., Z: 1.}, Rotation: testRotation}
+
+	if !CheckCollisionBoxOrientedBox(rl.BoundingBox{Min: rl.Vector3{X: 1.3, Y: -.1, Z: -.1}, Max: rl.Vector3{X: 1.5, Y: .1, Z: .1}}, oriented) {
+		t.Error("box at the corner of the rotated box doesn't collide")
+	}
+	if CheckCollisionBoxOrientedBox(rl.BoundingBox{Min: rl.Vector3{X: 1., Y: -.1, Z: 1.}, Max: rl.Vector3{X: 1.3, Y: .1, Z: 1.3}}, oriented) {
+		t.Error("box in the corner of the bounding box outside of the rotated box collides")
+	}
+
+	ray := rl.Ray{Position: rl.Vector3{X: 5., Y: 0., Z: 0.}, Direction: rl.Vector3{X: -1., Y: 0., Z: 0.}}
+	if collision := GetRayCollisionOrientedBox(ray, oriented); !collision.Hit || math32.Abs(collision.Distance-(5.-math32.Sqrt2)) > .001 {
+		t.Errorf("ray hit %+v, want the corner at distance %g", collision, 5.-math32.Sqrt2)
+	}
+}
+
+// The player slides along a rotated wall
+func TestOrientedWall(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	wall := OrientedBox{Center: rl.Vector3{X: -3., Y: 1., Z: 0.}, HalfExtents: rl.Vector3{X: .1, Y: 1., Z: 3.}, Rotation: testRotation}
+	world.AddOrientedBoundingBox(wall)
+
+	stepTestWorld(world, 90, getTestInputs(ControlForward))
+	if CheckCollisionBoxOrientedBox(world.Player.BoundingBox, wall) || world.Player.Position.Z > -.5 {
+		t.Errorf("player walked to %v, want slid along the wall to -Z", world.Player.Position)
+	}
+}
+
+// The player stands on a rotated crate and rotated trigger and interactable boxes work
+func TestOrientedBoxes(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 10., Y: 4., Z: 10.})
+	world.AddOrientedBoundingBox(OrientedBox{Center: rl.Vector3{X: 10., Y: .5, Z: 10.}, HalfExtents: rl.Vector3{X: .5, Y: .5, Z: .5}, Rotation: testRotation})
+	stepTestWorld(world, 120, [ControlCount]bool{})
+	if math32.Abs(world.Player.BoundingBox.Min.Y-1.) > .01 {
+		t.Fatalf("player is at Y %g, want on the crate at Y 1", world.Player.BoundingBox.Min.Y)
+	}
+
+	trigger := world.AddOrientedTriggerBox(OrientedBox{Center: rl.Vector3{X: 10., Y: 1.5, Z: 10.}, HalfExtents: rl.Vector3{X: .2, Y: .2, Z: .2}, Rotation: testRotation})
+	interactable := world.AddOrientedInteractableBox(OrientedBox{Center: rl.Vector3{X: 8.8, Y: world.Player.Camera.Position.Y, Z: 10.}, HalfExtents: rl.Vector3{X: .2, Y: .2, Z: .2}, Rotation: testRotation})
+	stepTestWorld(world, 1, [ControlCount]bool{})
+
+	if box, _ := world.GetTriggerBox(trigger); !box.Triggering {
+		t.Error("rotated trigger box isn't triggering")
+	}
+	if focus, ok := world.FocusedInteractable(); !ok || focus.ID != interactable || math32.Abs(focus.Distance-(1.2-.2*math32.Sqrt2)) > .01 {
+		t.Errorf("focused %+v, want the corner of the rotated interactable box", focus)
+	}
+}
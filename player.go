@@ -17,6 +17,9 @@ const (
 	ControlSprint
 	ControlZoom
 	ControlInteract
+	// Extra controls for alternative verbs of interactable boxes
+	ControlInteract2
+	ControlInteract3
 
 	ControlCount
 )
@@ -25,6 +28,8 @@ const (
 type Player struct {
 	// Player's constant speed variables
 	Speed PlayerSpeeds
+	// Tunables of QuakeController
+	Quake PlayerQuakeSpeeds
 	// Player's sensitivities when zooming or not
 	MouseSensitivity PlayerSensitivities
 	// Player's normal and zoom FOV
@@ -40,9 +45,19 @@ type Player struct {
 	IsCrouching bool
 	// Player's position Y is updated by this value
 	YVelocity float32
+	// Player's horizontal velocity, X is the velocity on the X axis and Y on the Z axis
+	HorizontalVelocity rl.Vector2
 	// How much the player jumps
 	JumpPower float32
-	// Used for moving when no keys are pressed
+	// Player's constant settings of coyote time, jump buffering and variable jump height
+	JumpAssists PlayerJumpAssists
+	// Time left to jump after leaving the ground, reset to player.JumpAssists.CoyoteTime every frame on the ground
+	CoyoteTimer float32
+	// Time left for the last press of the jump key to jump when the player lands
+	JumpBufferTimer float32
+	// If the player is rising from a jump, which is cut short when the jump key is released
+	IsJumping bool
+	// Last directional key pressed, the player no longer moves by it, player.HorizontalVelocity keeps the momentum instead
 	LastDirectionalKeyPressed int32
 	// Range where the player can interact with an interactable box
 	InteractRange float32
@@ -50,8 +65,19 @@ type Player struct {
 	AlreadyInteracted bool
 	// How high can the player step up
 	StepHeight float32
-	// Constant controls (setting)
+	// How strong the player is when pushing boxes, a box is pushed with the player's speed * PushStrength / (PushStrength + box's mass)
+	PushStrength float32
+	// Steepest slope in degrees the player can walk on, the player slides down steeper slopes
+	MaxSlopeAngle float32
+	// Constant controls (setting), used by KeyboardMouseInput
 	Controls [ControlCount]int32
+	// Where the controls are read from every frame, KeyboardMouseInput when nil
+	Input InputSource
+	// How the player moves (WalkController, QuakeController, FlyController, NoclipController, SpectatorController), can be switched at any time,
+	// WalkController when nil
+	Movement MovementController
+	// If the jump key was down in the last frame, used for jumping only when it's pressed again
+	WasJumpHeld bool
 	// Current keys that are down
 	CurrentInputs [ControlCount]bool
 	Camera        rl.Camera3D
@@ -63,10 +89,26 @@ type PlayerSpeeds struct {
 	Normal float32
 	Sprint float32
 	Sneak  float32
-	// Current speed
+	// Current horizontal speed, the length of player.HorizontalVelocity
 	Current float32
-	// Acceleration
+	// Acceleration on the ground
 	Acceleration float32
+	// Deceleration on the ground when no direction is held, has to be > 0
+	Friction float32
+	// Acceleration in the held direction in the air, the velocity in the held direction doesn't get over the max speed this way
+	AirAcceleration float32
+	// How fast the velocity turns towards the held direction in the air without changing the speed, 0 for not turning at all
+	AirControl float32
+}
+
+// Player's constant settings of coyote time, jump buffering and variable jump height
+type PlayerJumpAssists struct {
+	// How long in seconds the player can still jump after walking off a ledge, 0 for no coyote time
+	CoyoteTime float32
+	// How long in seconds a press of the jump key before landing is remembered, 0 for jumping only when the key is down on the ground
+	BufferTime float32
+	// Upward velocity is multiplied by this when the jump key is released while rising from a jump, 1 for a fixed jump height
+	CutMultiplier float32
 }
 
 // Player's sensitivities when zooming or not
@@ -93,6 +135,15 @@ func (player *Player) Init() {
 	player.Speed.Sprint = 6.
 	player.Speed.Sneak = 1.5
 	player.Speed.Acceleration = 25.
+	player.Speed.Friction = 25.
+	player.Speed.AirAcceleration = 8.
+	player.Speed.AirControl = 3.
+	player.Quake.Acceleration = 10.
+	player.Quake.AirAcceleration = 10.
+	player.Quake.Friction = 4.
+	player.Quake.StopSpeed = 2.5
+	player.Quake.AirSpeedCap = .75
+	player.Quake.AutoHop = false
 	player.MouseSensitivity.Normal = .0025
 	player.MouseSensitivity.Zoom = .0005
 	player.Fovs.Normal = 70.
@@ -101,8 +152,13 @@ func (player *Player) Init() {
 	player.ConstScale.Crouch = .9
 	player.Scale = rl.Vector3{X: .6, Y: player.ConstScale.Normal, Z: .6}
 	player.JumpPower = 5.
+	player.JumpAssists.CoyoteTime = .1
+	player.JumpAssists.BufferTime = .1
+	player.JumpAssists.CutMultiplier = .5
 	player.InteractRange = 3.
 	player.StepHeight = .4
+	player.PushStrength = 1.
+	player.MaxSlopeAngle = 45.
 	player.Controls[ControlForward] = rl.KeyW
 	player.Controls[ControlBackward] = rl.KeyS
 	player.Controls[ControlLeft] = rl.KeyA
@@ -112,6 +168,10 @@ func (player *Player) Init() {
 	player.Controls[ControlSprint] = rl.KeyLeftShift
 	player.Controls[ControlZoom] = rl.KeyC
 	player.Controls[ControlInteract] = rl.KeyE
+	player.Controls[ControlInteract2] = rl.KeyF
+	player.Controls[ControlInteract3] = rl.KeyG
+	player.Input = &KeyboardMouseInput{}
+	player.Movement = &WalkController{}
 }
 
 // Initializes player's values, should be called when loading a save or starting a new game
@@ -139,45 +199,91 @@ func (player *Player) New(position rl.Vector3, rotation rl.Vector2, is_crouching
 	}
 	player.IsCrouching = is_crouching
 	player.YVelocity = 0.
+	player.HorizontalVelocity = rl.Vector2{X: 0., Y: 0.}
+	player.CoyoteTimer = 0.
+	player.JumpBufferTimer = 0.
+	player.IsJumping = false
 	player.LastDirectionalKeyPressed = -1
 	player.AlreadyInteracted = false
+	player.WasJumpHeld = false
 	player.CurrentInputs = [ControlCount]bool{false, false, false, false, false, false, false, false, false}
 	player.InitCamera()
 }
 
 // Updating player, should be called every frame
+//
+// Deprecated: world.Update and world.Step update the player, use world.StepPlayer to update only the player with a known mouse movement
 func (world *World) UpdatePlayer() {
-	// Update variables that don't affect player's current position
-	world.UpdatePlayerVariables()
-	// Updates player's position and states
-	world.Player.UpdateRotation()
-	world.UpdatePlayerCrouch()
-	world.UpdatePlayerPosition()
+	inputs, mouse_delta := world.Player.getInput().Poll(&world.Player, world.FrameTime)
+	world.Player.CurrentInputs = inputs
+	world.StepPlayer(mouse_delta)
+}
+
+// Updates the player by the current inputs, called by world.Step
+//
+// #1 argument mouse_delta: rl.Vector2 - mouse movement since the last frame
+func (world *World) StepPlayer(mouse_delta rl.Vector2) {
+	// Rotate and move the player by the current movement controller
+	world.Player.getMovement().Update(world, mouse_delta)
+	world.Player.WasJumpHeld = world.Player.CurrentInputs[ControlJump]
 	// Move camera to player's position and rotate it
 	world.Player.UpdateCamera()
 }
 
 // Updates variables, that don't affect player's current position
 func (world *World) UpdatePlayerVariables() {
-	world.Player.UpdateCurrentInputs()
 	world.Player.UpdateLastDirectionalKeyPressed()
-	world.UpdatePlayerCurrentSpeed()
+	world.UpdatePlayerJumpTimers()
+	world.UpdatePlayerVelocity()
+}
+
+// Updates the coyote time and the jump buffer
+func (world *World) UpdatePlayerJumpTimers() {
+	// The player can jump for player.JumpAssists.CoyoteTime after leaving the ground
+	if world.Player.YVelocity == 0. && world.isPlayerOnGroundNextFrame() {
+		world.Player.CoyoteTimer = world.Player.JumpAssists.CoyoteTime
+	} else {
+		world.Player.CoyoteTimer = math32.Max(world.Player.CoyoteTimer-world.FrameTime, 0.)
+	}
+
+	// A press of the jump key is remembered for player.JumpAssists.BufferTime, so pressing it right before landing still jumps
+	if world.Player.CurrentInputs[ControlJump] && !world.Player.WasJumpHeld {
+		world.Player.JumpBufferTimer = world.Player.JumpAssists.BufferTime
+	} else {
+		world.Player.JumpBufferTimer = math32.Max(world.Player.JumpBufferTimer-world.FrameTime, 0.)
+	}
 }
 
-// Gets current keys down
+// Gets current keys down from player.Input
+//
+// Deprecated: world.Update reads player.Input every frame, world.Step takes the inputs as an argument
 func (player *Player) UpdateCurrentInputs() {
-	player.CurrentInputs[ControlForward] = rl.IsKeyDown(player.Controls[ControlForward])
-	player.CurrentInputs[ControlBackward] = rl.IsKeyDown(player.Controls[ControlBackward])
-	player.CurrentInputs[ControlLeft] = rl.IsKeyDown(player.Controls[ControlLeft])
-	player.CurrentInputs[ControlRight] = rl.IsKeyDown(player.Controls[ControlRight])
-	player.CurrentInputs[ControlJump] = rl.IsKeyDown(player.Controls[ControlJump])
-	player.CurrentInputs[ControlCrouch] = rl.IsKeyDown(player.Controls[ControlCrouch])
-	player.CurrentInputs[ControlSprint] = rl.IsKeyDown(player.Controls[ControlSprint])
-	player.CurrentInputs[ControlZoom] = rl.IsKeyDown(player.Controls[ControlZoom])
-	player.CurrentInputs[ControlInteract] = rl.IsKeyDown(player.Controls[ControlInteract])
+	player.CurrentInputs, _ = player.getInput().Poll(player, 0.)
+}
+
+// Gets where the controls are read from
+//
+// #1 return: InputSource - player.Input, KeyboardMouseInput when it's nil
+func (player *Player) getInput() InputSource {
+	if player.Input == nil {
+		return &KeyboardMouseInput{}
+	}
+
+	return player.Input
 }
 
-// Updates last key pressed, for moving without holding anything
+// Gets how the player moves
+//
+// #1 return: MovementController - player.Movement, WalkController when it's nil
+func (player *Player) getMovement() MovementController {
+	if player.Movement == nil {
+		return &WalkController{}
+	}
+
+	return player.Movement
+}
+
+// Updates last directional key pressed
 func (player *Player) UpdateLastDirectionalKeyPressed() {
 	if player.CurrentInputs[ControlForward] {
 		player.LastDirectionalKeyPressed = player.Controls[ControlForward]
@@ -193,57 +299,108 @@ func (player *Player) UpdateLastDirectionalKeyPressed() {
 	}
 }
 
+// Updates player's horizontal velocity and current speed
+// On the ground the velocity accelerates towards the held direction and slows down when nothing is held,
+// in the air the velocity is kept and can only be steered by player.Speed.AirAcceleration and player.Speed.AirControl
+func (world *World) UpdatePlayerVelocity() {
+	is_player_on_ground_next_frame := world.isPlayerOnGroundNextFrame()
+	direction := world.Player.GetWishDirection()
+	max_speed := world.Player.GetMaxSpeed(is_player_on_ground_next_frame)
+	velocity := world.Player.HorizontalVelocity
+	is_moving := direction.X != 0. || direction.Y != 0.
+
+	// The player leaves the ground when jumping, so the momentum isn't slowed down in the jump's frame
+	if is_player_on_ground_next_frame && !world.CanPlayerJump() {
+		if is_moving {
+			// Accelerate towards the held direction
+			velocity = rl.Vector2MoveTowards(velocity, rl.Vector2Scale(direction, max_speed), world.Player.Speed.Acceleration*world.FrameTime)
+		} else {
+			// Slow down to zero when nothing is held
+			velocity = rl.Vector2MoveTowards(velocity, rl.Vector2{X: 0., Y: 0.}, world.Player.Speed.Friction*world.FrameTime)
+		}
+	} else if is_moving {
+		// Turn the velocity towards the held direction without changing the speed
+		speed := rl.Vector2Length(velocity)
+		if speed > 0. && world.Player.Speed.AirControl > 0. {
+			turned := rl.Vector2Lerp(rl.Vector2Scale(velocity, 1./speed), direction, math32.Min(world.Player.Speed.AirControl*world.FrameTime, 1.))
+			if rl.Vector2Length(turned) > 1e-6 {
+				velocity = rl.Vector2Scale(rl.Vector2Normalize(turned), speed)
+			}
+		}
+
+		// Accelerate in the held direction, but not over the max speed in it
+		if along := rl.Vector2DotProduct(velocity, direction); along < max_speed {
+			velocity = rl.Vector2Add(velocity, rl.Vector2Scale(direction, math32.Min(world.Player.Speed.AirAcceleration*world.FrameTime, max_speed-along)))
+		}
+	}
+
+	world.Player.HorizontalVelocity = velocity
+	world.Player.Speed.Current = rl.Vector2Length(velocity)
+}
+
 // Updates player's current speed
+//
+// Deprecated: the player moves by player.HorizontalVelocity, world.UpdatePlayerVelocity updates it and player.Speed.Current
 func (world *World) UpdatePlayerCurrentSpeed() {
-	is_player_on_ground_next_frame := world.isPlayerOnGroundNextFrame()
+	world.UpdatePlayerVelocity()
+}
 
-	// When the player isn't holding anything, slow him to zero
-	if !world.Player.CurrentInputs[ControlForward] && !world.Player.CurrentInputs[ControlBackward] &&
-		!world.Player.CurrentInputs[ControlLeft] && !world.Player.CurrentInputs[ControlRight] {
+// Gets the horizontal direction of the held directional keys
+//
+// #1 return: rl.Vector2 - normalized direction, X is on the X axis and Y on the Z axis, zero when no direction is held
+func (player *Player) GetWishDirection() rl.Vector2 {
+	direction := rl.Vector2{X: 0., Y: 0.}
+	forward := rl.Vector2{X: -math32.Cos(player.Rotation.X), Y: -math32.Sin(player.Rotation.X)}
+	left := rl.Vector2{X: -math32.Sin(player.Rotation.X), Y: math32.Cos(player.Rotation.X)}
 
-		if world.Player.Speed.Current > 0. {
-			world.Player.Speed.Current -= world.Player.Speed.Acceleration * world.FrameTime
-			return
-		} else {
-			world.Player.Speed.Current = 0.
-			return
-		}
+	if player.CurrentInputs[ControlForward] {
+		direction = rl.Vector2Add(direction, forward)
 	}
-	// When the player is faster while crouching then he should be
-	if world.Player.IsCrouching && world.Player.Speed.Current > world.Player.Speed.Sneak {
-		world.Player.Speed.Current -= world.Player.Speed.Acceleration * world.FrameTime
-		return
+	if player.CurrentInputs[ControlBackward] {
+		direction = rl.Vector2Subtract(direction, forward)
 	}
-	// When the player is faster while not sprinting then he should be
-	if (!world.Player.CurrentInputs[ControlSprint] || !is_player_on_ground_next_frame) &&
-		world.Player.Speed.Current > world.Player.Speed.Normal {
-
-		world.Player.Speed.Current -= world.Player.Speed.Acceleration * world.FrameTime
-		return
+	if player.CurrentInputs[ControlLeft] {
+		direction = rl.Vector2Add(direction, left)
+	}
+	if player.CurrentInputs[ControlRight] {
+		direction = rl.Vector2Subtract(direction, left)
 	}
 
-	// Add speed, when the player is slower than he should be
-	if world.Player.Speed.Current <= world.Player.Speed.Normal && !world.Player.IsCrouching {
-		world.Player.Speed.Current += world.Player.Speed.Acceleration * world.FrameTime
-		return
+	// Opposite keys cancel each other out
+	if rl.Vector2Length(direction) < 1e-6 {
+		return rl.Vector2{X: 0., Y: 0.}
 	}
-	// Add speed, when the player is sprinting and is slower than he should be
-	if world.Player.CurrentInputs[ControlSprint] && world.Player.Speed.Current <= world.Player.Speed.Sprint {
-		world.Player.Speed.Current += world.Player.Speed.Acceleration * world.FrameTime
-		return
+
+	return rl.Vector2Normalize(direction)
+}
+
+// Gets the max horizontal speed of the player's current state
+//
+// #1 argument is_on_ground: bool - if the player is on the ground, sprinting only speeds the player up on the ground
+//
+// #1 return: float32 - the max speed
+func (player *Player) GetMaxSpeed(is_on_ground bool) float32 {
+	if player.IsCrouching {
+		return player.Speed.Sneak
 	}
-	// Add speed, when the player is crouching and is slower than he should be
-	if world.Player.IsCrouching && world.Player.Speed.Current <= world.Player.Speed.Sneak {
-		world.Player.Speed.Current += world.Player.Speed.Acceleration * world.FrameTime
-		return
+	if player.CurrentInputs[ControlSprint] && is_on_ground {
+		return player.Speed.Sprint
 	}
+
+	return player.Speed.Normal
 }
 
 // Updates player's rotation
+//
+// Deprecated: world.Step rotates the player by the mouse movement passed to it, use player.Rotate to rotate by a known mouse movement
 func (player *Player) UpdateRotation() {
-	// Current mouse movement
-	mouse_delta := rl.GetMouseDelta()
+	player.Rotate(rl.GetMouseDelta())
+}
 
+// Rotates the player by a mouse movement
+//
+// #1 argument mouse_delta: rl.Vector2 - mouse movement since the last frame
+func (player *Player) Rotate(mouse_delta rl.Vector2) {
 	// Rotate player with according sensitivity
 	if player.CurrentInputs[ControlZoom] {
 		player.Rotation.X += mouse_delta.X * player.MouseSensitivity.Zoom
@@ -268,7 +425,7 @@ func (world *World) UpdatePlayerCrouch() {
 	if world.Player.CurrentInputs[ControlCrouch] {
 		if !world.Player.IsCrouching {
 			world.Player.Scale.Y = world.Player.ConstScale.Crouch
-			world.Player.Position.Y -= world.Player.ConstScale.Crouch / 2
+			world.Player.Position.Y -= (world.Player.ConstScale.Normal - world.Player.ConstScale.Crouch) / 2
 			world.Player.BoundingBox.Max.Y = world.Player.BoundingBox.Min.Y + world.Player.ConstScale.Crouch
 			world.Player.IsCrouching = true
 
@@ -281,7 +438,7 @@ func (world *World) UpdatePlayerCrouch() {
 	// Set player to normal state
 	if world.Player.IsCrouching && world.CanPlayerUncrouch() {
 		world.Player.Scale.Y = world.Player.ConstScale.Normal
-		world.Player.Position.Y += world.Player.ConstScale.Normal / 2
+		world.Player.Position.Y += (world.Player.ConstScale.Normal - world.Player.ConstScale.Crouch) / 2
 		world.Player.BoundingBox.Max.Y = world.Player.BoundingBox.Min.Y + world.Player.ConstScale.Normal
 		world.Player.IsCrouching = false
 
@@ -296,237 +453,105 @@ func (world *World) CanPlayerUncrouch() bool {
 	bounding_box_next_frame := world.Player.BoundingBox
 	bounding_box_next_frame.Max.Y = bounding_box_next_frame.Min.Y + world.Player.ConstScale.Normal
 
-	for i := range world.BoundingBoxes {
-		if rl.CheckCollisionBoxes(bounding_box_next_frame, world.BoundingBoxes[i]) && i != 0 {
-			return false
-		}
+	return !world.isPlayerBlocked(bounding_box_next_frame, -1)
+}
+
+// Checks if the player jumps this frame
+// With QuakeController and without player.Quake.AutoHop the jump key has to be pressed again for every jump
+//
+// #1 return: bool - true if the jump key is pressed or buffered and the player is standing on the ground or in coyote time
+func (world *World) CanPlayerJump() bool {
+	if world.Player.IsCrouching {
+		return false
 	}
 
-	return true
+	is_jump_pressed := world.Player.JumpBufferTimer > 0. || (world.Player.CurrentInputs[ControlJump] &&
+		(!world.isJumpPressRequired() || !world.Player.WasJumpHeld))
+	if !is_jump_pressed {
+		return false
+	}
+
+	return world.Player.CoyoteTimer > 0. || (world.Player.YVelocity == 0. && world.isPlayerOnGroundNextFrame())
+}
+
+// Checks if the jump key has to be pressed again for every jump
+//
+// #1 return: bool - true with QuakeController without player.Quake.AutoHop
+func (world *World) isJumpPressRequired() bool {
+	_, is_quake := world.Player.Movement.(*QuakeController)
+
+	return is_quake && !world.Player.Quake.AutoHop
 }
 
 // Updates player's position and bounding box
 func (world *World) UpdatePlayerPosition() {
 	// Jump when the player is on the ground and the jump key is pressed
-	if world.Player.CurrentInputs[ControlJump] && world.Player.YVelocity == 0. &&
-		world.isPlayerOnGroundNextFrame() && !world.Player.IsCrouching {
+	if world.CanPlayerJump() {
 		world.Player.YVelocity = world.Player.JumpPower
+		world.Player.IsJumping = true
+		// The coyote time and the buffered press are used up by the jump
+		world.Player.CoyoteTimer = 0.
+		world.Player.JumpBufferTimer = 0.
+	} else if world.Player.IsJumping && (world.Player.YVelocity <= 0. || !world.Player.CurrentInputs[ControlJump]) {
+		// Releasing the jump key while rising cuts the jump short
+		if world.Player.YVelocity > 0. {
+			world.Player.YVelocity *= world.Player.JumpAssists.CutMultiplier
+		}
+		world.Player.IsJumping = false
+	}
+
+	// Pop the player up when they're partly under the ground
+	if world.Player.BoundingBox.Min.Y < world.Ground && world.Player.BoundingBox.Max.Y > world.Ground {
+		world.setPlayerBoundingBox(moveBox(world.Player.BoundingBox, rl.Vector3{X: 0., Y: world.Ground + world.FloatPrecision - world.Player.BoundingBox.Min.Y, Z: 0.}))
+		world.Player.YVelocity = 0.
 	}
 
 	// Get player's offsets for the next frame
+	world.Player.YVelocity -= world.Gravity * world.FrameTime
 	world.UpdatePlayerOffsetNextFrame()
 
-	// Update player's position Y and Y velocity
-	if world.Player.OffsetNextFrame.Y != 0 {
-		world.UpdatePlayerPositionY()
-	} else {
-		world.Player.YVelocity -= world.Gravity * world.FrameTime
+	// Walk along the walkable ground under the player, unless the player is jumping
+	ground := world.getPlayerGround()
+	is_walking := ground.Hit && world.Player.isWalkable(ground.Normal) && world.Player.YVelocity <= 0.
+	if is_walking {
+		world.Player.YVelocity = 0.
+		world.Player.OffsetNextFrame.Y = getSlopeOffsetY(world.Player.OffsetNextFrame, ground.Normal)
 	}
 
-	// Update player's position X
-	if world.Player.OffsetNextFrame.X != 0 {
-		world.UpdatePlayerPositionX()
-	}
-	// Update player's position Z
-	if world.Player.OffsetNextFrame.Z != 0 {
-		world.UpdatePlayerPositionZ()
+	// Move the player and slide along everything in the way
+	start := world.Player.BoundingBox.Min
+	world.MovePlayer(world.Player.OffsetNextFrame)
+
+	// Stay on the ground when walking down a slope
+	if is_walking {
+		world.snapPlayerToGround(rl.Vector2Length(rl.Vector2{X: world.Player.BoundingBox.Min.X - start.X, Y: world.Player.BoundingBox.Min.Z - start.Z}))
 	}
 }
 
 // Gets player's offsets for the next frame
 func (world *World) UpdatePlayerOffsetNextFrame() {
-	offset := rl.Vector2{X: 0., Y: 0.}
-	// Get player's current speed
-	current_speed := world.Player.Speed.Current
-
-	// Get the number of keys pressed
-	keys_pressed := 0
-	if world.Player.CurrentInputs[ControlForward] {
-		keys_pressed++
-	}
-	if world.Player.CurrentInputs[ControlBackward] {
-		keys_pressed++
-	}
-	if world.Player.CurrentInputs[ControlLeft] {
-		keys_pressed++
-	}
-	if world.Player.CurrentInputs[ControlRight] {
-		keys_pressed++
-	}
-	// If you're moving diagonally (2 keys) then multiply by math32.Sqrt(2)
-	if keys_pressed == 2 {
-		current_speed *= .707
-	}
-
-	current_speed *= world.FrameTime
-
-	// Calculate the offsets according to player's inputs
-	if world.Player.CurrentInputs[ControlForward] || world.Player.LastDirectionalKeyPressed == world.Player.Controls[ControlForward] {
-		offset.X -= math32.Cos(world.Player.Rotation.X) * current_speed
-		offset.Y -= math32.Sin(world.Player.Rotation.X) * current_speed
-	}
-	if world.Player.CurrentInputs[ControlBackward] || world.Player.LastDirectionalKeyPressed == world.Player.Controls[ControlBackward] {
-		offset.X += math32.Cos(world.Player.Rotation.X) * current_speed
-		offset.Y += math32.Sin(world.Player.Rotation.X) * current_speed
-	}
-	if world.Player.CurrentInputs[ControlLeft] || world.Player.LastDirectionalKeyPressed == world.Player.Controls[ControlLeft] {
-		offset.Y += math32.Cos(world.Player.Rotation.X) * current_speed
-		offset.X -= math32.Sin(world.Player.Rotation.X) * current_speed
-	}
-	if world.Player.CurrentInputs[ControlRight] || world.Player.LastDirectionalKeyPressed == world.Player.Controls[ControlRight] {
-		offset.Y -= math32.Cos(world.Player.Rotation.X) * current_speed
-		offset.X += math32.Sin(world.Player.Rotation.X) * current_speed
-	}
-
-	// Update player's offsets
-	world.Player.OffsetNextFrame.X = offset.X
+	world.Player.OffsetNextFrame.X = world.Player.HorizontalVelocity.X * world.FrameTime
 	world.Player.OffsetNextFrame.Y = world.Player.YVelocity * world.FrameTime
-	world.Player.OffsetNextFrame.Z = offset.Y
+	world.Player.OffsetNextFrame.Z = world.Player.HorizontalVelocity.Y * world.FrameTime
 }
 
 // Updates player's position X
+//
+// Deprecated: world.UpdatePlayerPosition moves the player on every axis at once by world.MovePlayer
 func (world *World) UpdatePlayerPositionX() {
-	// Check collisions in the X axis
-	if i, t := world.checkPlayerCollisionsXNextFrame(); i != -1 {
-		// Check if the player will be colliding with an object when stepping up
-		if world.BoundingBoxes[i].Max.Y-world.Player.BoundingBox.Min.Y <= world.Player.StepHeight && world.isPlayerOnGroundNextFrame() {
-			if !world.checkPlayerCollisionsXYNextFrame(world.BoundingBoxes[i].Max.Y + world.FloatPrecision) {
-				// Move player in the X axis
-				world.Player.BoundingBox.Min.X += world.Player.OffsetNextFrame.X
-				world.Player.BoundingBox.Max.X += world.Player.OffsetNextFrame.X
-				world.Player.Position.X += world.Player.OffsetNextFrame.X
-
-				// Move the player in the Y axis
-				world.Player.BoundingBox.Min.Y = world.BoundingBoxes[i].Max.Y + world.FloatPrecision
-				world.Player.BoundingBox.Max.Y = world.Player.BoundingBox.Min.Y + world.Player.Scale.Y
-				world.Player.Position.Y = world.Player.BoundingBox.Min.Y + world.Player.Scale.Y/2
-				return
-			}
-		}
-
-		if t {
-			// Align to an object when moving in positive X axis
-			world.Player.BoundingBox.Max.X = world.BoundingBoxes[i].Min.X - world.FloatPrecision
-			world.Player.BoundingBox.Min.X = world.Player.BoundingBox.Max.X - world.Player.Scale.X
-			world.Player.Position.X = world.Player.BoundingBox.Min.X + world.Player.Scale.X/2
-
-			return
-		} else {
-			// Align to an object when moving in negative X axis
-			world.Player.BoundingBox.Min.X = world.BoundingBoxes[i].Max.X + world.FloatPrecision
-			world.Player.BoundingBox.Max.X = world.Player.BoundingBox.Min.X + world.Player.Scale.X
-			world.Player.Position.X = world.Player.BoundingBox.Min.X + world.Player.Scale.X/2
-
-			return
-		}
-	}
-
-	// Move player in the X axis
-	world.Player.BoundingBox.Min.X += world.Player.OffsetNextFrame.X
-	world.Player.BoundingBox.Max.X += world.Player.OffsetNextFrame.X
-	world.Player.Position.X += world.Player.OffsetNextFrame.X
+	world.MovePlayer(rl.Vector3{X: world.Player.OffsetNextFrame.X, Y: 0., Z: 0.})
 }
 
 // Updates player's position Y
+//
+// Deprecated: world.UpdatePlayerPosition moves the player on every axis at once by world.MovePlayer
 func (world *World) UpdatePlayerPositionY() {
-	// Check if the player is on the ground
-	if world.Player.BoundingBox.Min.Y+world.Player.OffsetNextFrame.Y < world.Ground &&
-		(world.Player.BoundingBox.Max.Y > world.Ground ||
-			world.Player.BoundingBox.Min.Y+world.Player.OffsetNextFrame.Y+world.Player.StepHeight > world.Ground) {
-
-		// Reset player's Y velocity when colliding with the ground
-		world.Player.YVelocity = 0.
-
-		// Check if the player will be colliding with an object when moving in the Y axis
-		if i := world.checkPlayerCollisionsYOnGround(); i != -1 {
-			// Align to an object when colliding
-			world.Player.BoundingBox.Max.Y = world.BoundingBoxes[i].Min.Y - world.FloatPrecision
-			world.Player.BoundingBox.Min.Y = world.Player.BoundingBox.Max.Y - world.Player.Scale.Y
-			world.Player.Position.Y = world.Player.BoundingBox.Min.Y + world.Player.Scale.Y/2
-
-			return
-		} else {
-			// Align to the ground
-			world.Player.BoundingBox.Min.Y = world.Ground + world.FloatPrecision
-			world.Player.BoundingBox.Max.Y = world.Player.BoundingBox.Min.Y + world.Player.Scale.Y
-			world.Player.Position.Y = world.Player.BoundingBox.Min.Y + world.Player.Scale.Y/2
-
-			return
-		}
-	}
-
-	// Check collisions in the Y axis
-	if i, t := world.checkPlayerCollisionsYNextFrame(); i != -1 {
-		// Reset player's Y velocity when colliding with an object
-		world.Player.YVelocity = 0.
-
-		if t {
-			// Align to an object when moving in positive Y axis
-			world.Player.BoundingBox.Max.Y = world.BoundingBoxes[i].Min.Y - world.FloatPrecision
-			world.Player.BoundingBox.Min.Y = world.Player.BoundingBox.Max.Y - world.Player.Scale.Y
-			world.Player.Position.Y = world.Player.BoundingBox.Min.Y + world.Player.Scale.Y/2
-
-			world.Player.OffsetNextFrame.Y = 0.
-
-			return
-		} else {
-			// Align to an object when moving in negative Y axis
-			world.Player.BoundingBox.Min.Y = world.BoundingBoxes[i].Max.Y + world.FloatPrecision
-			world.Player.BoundingBox.Max.Y = world.Player.BoundingBox.Min.Y + world.Player.Scale.Y
-			world.Player.Position.Y = world.Player.BoundingBox.Min.Y + world.Player.Scale.Y/2
-
-			return
-		}
-	}
-
-	// Move player in the Y axis
-	world.Player.BoundingBox.Min.Y += world.Player.OffsetNextFrame.Y
-	world.Player.BoundingBox.Max.Y += world.Player.OffsetNextFrame.Y
-	world.Player.Position.Y += world.Player.OffsetNextFrame.Y
-
-	// Update player's Y velocity
-	world.Player.YVelocity -= world.Gravity * world.FrameTime
+	world.MovePlayer(rl.Vector3{X: 0., Y: world.Player.OffsetNextFrame.Y, Z: 0.})
 }
 
 // Updates player's position Z
+//
+// Deprecated: world.UpdatePlayerPosition moves the player on every axis at once by world.MovePlayer
 func (world *World) UpdatePlayerPositionZ() {
-	// Check collisions in the Z axis
-	if i, t := world.checkPlayerCollisionsZNextFrame(); i != -1 {
-		// Check if the player will be colliding with an object when stepping up
-		if world.BoundingBoxes[i].Max.Y-world.Player.BoundingBox.Min.Y <= world.Player.StepHeight && world.isPlayerOnGroundNextFrame() {
-			if !world.checkPlayerCollisionsZYNextFrame(world.BoundingBoxes[i].Max.Y + world.FloatPrecision) {
-				// Move player in the X axis
-				world.Player.BoundingBox.Min.Z += world.Player.OffsetNextFrame.Z
-				world.Player.BoundingBox.Max.Z += world.Player.OffsetNextFrame.Z
-				world.Player.Position.Z += world.Player.OffsetNextFrame.Z
-
-				// Move the player in the Y axis
-				world.Player.BoundingBox.Min.Y = world.BoundingBoxes[i].Max.Y + world.FloatPrecision
-				world.Player.BoundingBox.Max.Y = world.Player.BoundingBox.Min.Y + world.Player.Scale.Y
-				world.Player.Position.Y = world.Player.BoundingBox.Min.Y + world.Player.Scale.Y/2
-				return
-			}
-		}
-
-		if t {
-			// Align to an object when moving in positive Z axis
-			world.Player.BoundingBox.Max.Z = world.BoundingBoxes[i].Min.Z - world.FloatPrecision
-			world.Player.BoundingBox.Min.Z = world.Player.BoundingBox.Max.Z - world.Player.Scale.Z
-			world.Player.Position.Z = world.Player.BoundingBox.Min.Z + world.Player.Scale.Z/2
-
-			return
-		} else {
-			// Align to an object when moving in negative Z axis
-			world.Player.BoundingBox.Min.Z = world.BoundingBoxes[i].Max.Z + world.FloatPrecision
-			world.Player.BoundingBox.Max.Z = world.Player.BoundingBox.Min.Z + world.Player.Scale.Z
-			world.Player.Position.Z = world.Player.BoundingBox.Min.Z + world.Player.Scale.Z/2
-
-			return
-		}
-	}
-
-	// Move player in the Z axis
-	world.Player.BoundingBox.Min.Z += world.Player.OffsetNextFrame.Z
-	world.Player.BoundingBox.Max.Z += world.Player.OffsetNextFrame.Z
-	world.Player.Position.Z += world.Player.OffsetNextFrame.Z
+	world.MovePlayer(rl.Vector3{X: 0., Y: 0., Z: world.Player.OffsetNextFrame.Z})
 }
@@ -0,0 +1,165 @@
+package rlfp
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"github.com/chewxy/math32"
+)
+
+// The player accelerates to the max speed and stops by friction
+func TestHorizontalVelocity(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	stepTestWorld(world, 30, [ControlCount]bool{})
+
+	stepTestWorld(world, 1, getTestInputs(ControlForward, ControlSprint))
+	if world.Player.Speed.Current <= 0. || world.Player.Speed.Current >= world.Player.Speed.Sprint {
+		t.Errorf("speed is %g after one frame, want between 0 and %g", world.Player.Speed.Current, world.Player.Speed.Sprint)
+	}
+
+	stepTestWorld(world, 60, getTestInputs(ControlForward, ControlSprint))
+	if world.Player.HorizontalVelocity != (rl.Vector2{X: -world.Player.Speed.Sprint, Y: 0.}) {
+		t.Errorf("velocity is %v after running, want %g forward", world.Player.HorizontalVelocity, world.Player.Speed.Sprint)
+	}
+
+	stepTestWorld(world, 20, [ControlCount]bool{})
+	if world.Player.Speed.Current != 0. || world.Player.HorizontalVelocity != (rl.Vector2{X: 0., Y: 0.}) {
+		t.Errorf("velocity is %v after releasing the keys, want stopped", world.Player.HorizontalVelocity)
+	}
+}
+
+// The player keeps the velocity in the air when turning and steers it only by air control
+func TestAirMomentum(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	stepTestWorld(world, 60, getTestInputs(ControlForward, ControlSprint))
+	velocity := world.Player.HorizontalVelocity
+
+	stepTestWorld(world, 1, getTestInputs(ControlJump))
+	for i := 0; i < 10; i++ {
+		world.Step(1./60., [ControlCount]bool{}, rl.Vector2{X: 100., Y: 0.})
+	}
+	if world.Player.BoundingBox.Min.Y < .1 || world.Player.HorizontalVelocity != velocity {
+		t.Fatalf("velocity is %v at Y %g after turning in the air, want %v in the air", world.Player.HorizontalVelocity, world.Player.BoundingBox.Min.Y, velocity)
+	}
+
+	stepTestWorld(world, 5, getTestInputs(ControlForward))
+	speed := rl.Vector2Length(world.Player.HorizontalVelocity)
+	if world.Player.HorizontalVelocity == velocity || speed > world.Player.Speed.Sprint+.001 {
+		t.Errorf("velocity is %v after air control, want turned without speeding up", world.Player.HorizontalVelocity)
+	}
+	if math32.Abs(speed-world.Player.Speed.Current) > .001 {
+		t.Errorf("current speed %g isn't the length of the velocity %g", world.Player.Speed.Current, speed)
+	}
+}
+
+// Checks if the player started a jump in the last step
+//
+// #1 argument world: *World - the world
+//
+// #2 argument previous: float32 - player's Y velocity before the step
+//
+// #1 return: bool - true if the player jumped
+func isTestJumpStarted(world *World, previous float32) bool {
+	return world.Player.YVelocity > world.Player.JumpPower-1. && previous < world.Player.JumpPower-1.
+}
+
+// Jumps and gets the highest point of the jump
+//
+// #1 argument hold: int - for how many frames the jump key is held
+//
+// #1 return: float32 - the highest Y position of the bottom of the player
+func getTestJumpHeight(hold int) float32 {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	stepTestWorld(world, 30, [ControlCount]bool{})
+
+	max_y := float32(0.)
+	for i := 0; i < 90; i++ {
+		inputs := [ControlCount]bool{}
+		inputs[ControlJump] = i < hold
+		world.Step(1./60., inputs, rl.Vector2{X: 0., Y: 0.})
+		max_y = math32.Max(max_y, world.Player.BoundingBox.Min.Y)
+	}
+
+	return max_y
+}
+
+// Releasing the jump key early cuts the jump
+func TestVariableJumpHeight(t *testing.T) {
+	tap, short, hold := getTestJumpHeight(1), getTestJumpHeight(6), getTestJumpHeight(90)
+
+	if !(tap < short && short < hold) || hold < .7 {
+		t.Errorf("jumps are %g, %g and %g high, want higher the longer the key is held", tap, short, hold)
+	}
+}
+
+// The player can jump for a short time after walking off a ledge
+func TestCoyoteTime(t *testing.T) {
+	for delay, want := range map[int]bool{1: true, 5: true, 8: false, 12: false} {
+		world := newTestWorld(rl.Vector3{X: 0., Y: 3., Z: 0.})
+		world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: -1., Y: 0., Z: -1.}, Max: rl.Vector3{X: 1., Y: 2., Z: 1.}})
+
+		left, jumped := -1, false
+		for i := 0; i < 120; i++ {
+			inputs := getTestInputs(ControlForward)
+			inputs[ControlJump] = left >= 0 && i == left+delay
+			previous := world.Player.YVelocity
+			world.Step(1./60., inputs, rl.Vector2{X: 0., Y: 0.})
+			if left < 0 && world.Player.BoundingBox.Min.Y < 2. {
+				left = i
+			}
+			jumped = jumped || isTestJumpStarted(world, previous)
+		}
+
+		if jumped != want {
+			t.Errorf("jump %d frames after leaving the ledge jumped %t, want %t", delay, jumped, want)
+		}
+	}
+}
+
+// A jump pressed shortly before landing jumps on landing
+func TestJumpBuffer(t *testing.T) {
+	landing := 0
+	for world := newTestWorld(rl.Vector3{X: 0., Y: 4., Z: 0.}); !world.isPlayerOnGroundNextFrame(); landing++ {
+		stepTestWorld(world, 1, [ControlCount]bool{})
+	}
+
+	for early, want := range map[int]bool{2: true, 5: true, 8: false, 12: false} {
+		world := newTestWorld(rl.Vector3{X: 0., Y: 4., Z: 0.})
+
+		jumped := false
+		for i := 0; i < 120; i++ {
+			inputs := [ControlCount]bool{}
+			inputs[ControlJump] = i == landing-early
+			previous := world.Player.YVelocity
+			world.Step(1./60., inputs, rl.Vector2{X: 0., Y: 0.})
+			jumped = jumped || isTestJumpStarted(world, previous)
+		}
+
+		if jumped != want {
+			t.Errorf("jump %d frames before landing jumped %t, want %t", early, jumped, want)
+		}
+	}
+}
+
+// The jump assists and their timers are restored from the state and replayed
+func TestJumpAssistsState(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	world.Player.JumpAssists = PlayerJumpAssists{CoyoteTime: 0., BufferTime: .3, CutMultiplier: 1.}
+	state := world.GetState()
+	world.Player.JumpAssists = PlayerJumpAssists{CoyoteTime: .1, BufferTime: .1, CutMultiplier: .5}
+	world.SetState(state)
+	if world.Player.JumpAssists != state.Player.JumpAssists {
+		t.Errorf("restored jump assists %+v, want %+v", world.Player.JumpAssists, state.Player.JumpAssists)
+	}
+
+	world.StartRecording()
+	for i := 0; i < 100; i++ {
+		inputs := getTestInputs(ControlForward)
+		inputs[ControlJump] = i%17 < 3
+		world.Advance(1./60., inputs, rl.Vector2{X: 1., Y: 0.})
+	}
+	if err := world.Replay(world.StopRecording()); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,50 @@
+package rlfp
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Sets if the player can push a dynamic box by walking into it
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #2 argument pushable: bool - if the box can be pushed, only dynamic boxes can be pushed
+//
+// #1 return: bool - false if there is no dynamic bounding box with the ID
+func (world *World) SetBoundingBoxPushable(id BoxID, pushable bool) bool {
+	i := world.getBoxIndex(&world.bounding_box_indexes, len(world.BoundingBoxes), id)
+	if i == -1 || !world.BoundingBoxes[i].Dynamic {
+		return false
+	}
+
+	world.BoundingBoxes[i].Pushable = pushable
+
+	return true
+}
+
+// Pushes a pushable box the player walked into, the box moves slower the heavier it is and stops when something is in the way
+//
+// #1 argument i: int - index of the bounding box
+//
+// #2 argument axis: int - the axis the player is moving on (axisX, axisZ)
+//
+// #3 argument offset: float32 - how far the player wants to move on the axis
+//
+// #1 return: bool - true if the box moved
+func (world *World) pushBoundingBox(i int, axis int, offset float32) bool {
+	box := &world.BoundingBoxes[i]
+	if !box.Dynamic || !box.Pushable || world.Player.PushStrength <= 0. {
+		return false
+	}
+
+	distance := offset * world.Player.PushStrength / (world.Player.PushStrength + box.Mass)
+	moved, _ := world.getRigidBoxMove(i, axis, distance)
+	if moved == 0. {
+		return false
+	}
+
+	world.moveBoundingBox(i, rl.Vector3Add(box.BoundingBox.Min, setAxis(rl.Vector3{X: 0., Y: 0., Z: 0.}, axis, moved)))
+	box.Resting = false
+
+	return true
+}
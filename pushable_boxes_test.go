@@ -0,0 +1,59 @@
+package rlfp
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"github.com/chewxy/math32"
+)
+
+// Walks the player forward into a crate for some frames
+//
+// #1 argument mass: float32 - mass of the crate
+//
+// #2 argument pushable: bool - if the crate can be pushed
+//
+// #3 argument frames: int - number of frames
+//
+// #1 return: *World - the world, the crate is the first bounding box and a wall is behind it at X -7
+func pushTestCrate(mass float32, pushable bool, frames int) *World {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	crate := world.AddRigidBox(getTestCrate(-3., 0.), mass)
+	world.SetBoundingBoxPushable(crate, pushable)
+	world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: -8., Y: 0., Z: -2.}, Max: rl.Vector3{X: -7., Y: 3., Z: 2.}})
+
+	stepTestWorld(world, frames, getTestInputs(ControlForward))
+
+	return world
+}
+
+// The player pushes a crate until it hits a wall
+func TestPushCrateToWall(t *testing.T) {
+	world := pushTestCrate(1., true, 300)
+	crate := world.BoundingBoxes[0].BoundingBox
+
+	if math32.Abs(crate.Min.X+7.) > .01 {
+		t.Errorf("crate was pushed to X %g, want to the wall at X -7", crate.Min.X)
+	}
+	if math32.Abs(world.Player.BoundingBox.Min.X-crate.Max.X) > .01 {
+		t.Errorf("player stopped at X %g, want at the crate at X %g", world.Player.BoundingBox.Min.X, crate.Max.X)
+	}
+	if world.Player.Position.Y > 1. {
+		t.Errorf("player climbed the crate to Y %g", world.Player.Position.Y)
+	}
+}
+
+// Heavier crates are pushed slower and crates which aren't pushable don't move
+func TestPushCrateMass(t *testing.T) {
+	light := pushTestCrate(1., true, 90).BoundingBoxes[0].BoundingBox.Min.X
+	heavy := pushTestCrate(3., true, 90).BoundingBoxes[0].BoundingBox.Min.X
+	fixed := pushTestCrate(1., false, 90).BoundingBoxes[0].BoundingBox.Min.X
+
+	if !(light < heavy && heavy < -3.) {
+		t.Errorf("light crate was pushed to X %g and heavy to X %g, want both moved and the light one farther", light, heavy)
+	}
+	if fixed != -3. {
+		t.Errorf("crate which isn't pushable moved to X %g", fixed)
+	}
+}
@@ -0,0 +1,89 @@
+package rlfp
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"github.com/chewxy/math32"
+)
+
+// Player's tunables of the Quake/Source movement model, the max speeds are taken from player.Speed
+type PlayerQuakeSpeeds struct {
+	// Acceleration on the ground, multiplied by the max speed
+	Acceleration float32
+	// Acceleration in the air, multiplied by the max speed
+	AirAcceleration float32
+	// Fraction of the speed lost every second on the ground, has to be > 0
+	Friction float32
+	// Speeds under this are slowed down by friction as if the player was moving at this speed, so the player stops quickly
+	StopSpeed float32
+	// Max speed gained in the held direction in the air, a low cap makes strafe-jumping possible
+	AirSpeedCap float32
+	// If holding the jump key jumps again right after landing, otherwise the key has to be pressed again
+	AutoHop bool
+}
+
+// Updates player's horizontal velocity with the Quake/Source movement, used by QuakeController
+// Friction is skipped in the frame the player jumps, so jumping right after landing keeps the speed
+func (world *World) UpdatePlayerQuakeVelocity() {
+	direction := world.Player.GetWishDirection()
+	is_player_on_ground_next_frame := world.isPlayerOnGroundNextFrame()
+	max_speed := world.Player.GetMaxSpeed(is_player_on_ground_next_frame)
+	velocity := world.Player.HorizontalVelocity
+
+	if is_player_on_ground_next_frame && !world.CanPlayerJump() {
+		velocity = applyQuakeFriction(velocity, world.Player.Quake.Friction, world.Player.Quake.StopSpeed, world.FrameTime)
+		velocity = quakeAccelerate(velocity, direction, max_speed, max_speed, world.Player.Quake.Acceleration, world.FrameTime)
+	} else {
+		velocity = quakeAccelerate(velocity, direction, math32.Min(max_speed, world.Player.Quake.AirSpeedCap), max_speed, world.Player.Quake.AirAcceleration, world.FrameTime)
+	}
+
+	world.Player.HorizontalVelocity = velocity
+	world.Player.Speed.Current = rl.Vector2Length(velocity)
+}
+
+// Accelerates a velocity in a direction like PM_Accelerate
+//
+// #1 argument velocity: rl.Vector2 - the velocity
+//
+// #2 argument direction: rl.Vector2 - normalized direction, zero for not accelerating
+//
+// #3 argument wish_speed: float32 - speed in the direction the velocity can get up to
+//
+// #4 argument max_speed: float32 - speed the acceleration is multiplied by
+//
+// #5 argument acceleration: float32 - the acceleration
+//
+// #6 argument frame_time: float32 - time of the frame in seconds
+//
+// #1 return: rl.Vector2 - the accelerated velocity
+func quakeAccelerate(velocity rl.Vector2, direction rl.Vector2, wish_speed float32, max_speed float32, acceleration float32, frame_time float32) rl.Vector2 {
+	// Only the speed in the direction is limited, so turning while moving sideways adds speed
+	added := wish_speed - rl.Vector2DotProduct(velocity, direction)
+	if added <= 0. {
+		return velocity
+	}
+
+	return rl.Vector2Add(velocity, rl.Vector2Scale(direction, math32.Min(acceleration*max_speed*frame_time, added)))
+}
+
+// Slows a velocity down like PM_Friction
+//
+// #1 argument velocity: rl.Vector2 - the velocity
+//
+// #2 argument friction: float32 - fraction of the speed lost every second
+//
+// #3 argument stop_speed: float32 - lower speeds are slowed down as if they were this fast
+//
+// #4 argument frame_time: float32 - time of the frame in seconds
+//
+// #1 return: rl.Vector2 - the slowed down velocity
+func applyQuakeFriction(velocity rl.Vector2, friction float32, stop_speed float32, frame_time float32) rl.Vector2 {
+	speed := rl.Vector2Length(velocity)
+	if speed < 1e-4 {
+		return rl.Vector2{X: 0., Y: 0.}
+	}
+
+	slowed := math32.Max(speed-math32.Max(speed, stop_speed)*friction*frame_time, 0.)
+
+	return rl.Vector2Scale(velocity, slowed/speed)
+}
@@ -0,0 +1,88 @@
+package rlfp
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Creates a world with the player running forward with the Quake movement for a second
+//
+// #1 argument auto_hop: bool - if holding the jump key jumps again on landing
+//
+// #1 return: *World - the new world
+func newQuakeTestWorld(auto_hop bool) *World {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	world.Player.Movement = &QuakeController{}
+	world.Player.Quake.AutoHop = auto_hop
+	stepTestWorld(world, 60, getTestInputs(ControlForward))
+
+	return world
+}
+
+// Steps a world and counts the jumps
+//
+// #1 argument world: *World - the world
+//
+// #2 argument frames: int - number of frames
+//
+// #3 argument strafe: bool - if the player strafes left and right with the mouse instead of going forward
+//
+// #1 return: int - number of jumps
+func hopQuakeTestWorld(world *World, frames int, strafe bool) int {
+	jumps := 0
+	for i := 0; i < frames; i++ {
+		inputs := getTestInputs(ControlForward, ControlJump)
+		look_delta := rl.Vector2{X: 0., Y: 0.}
+		if strafe {
+			left := (i/30)%2 == 0
+			inputs[ControlForward] = false
+			inputs[ControlLeft] = left
+			inputs[ControlRight] = !left
+			look_delta.X = 6.
+			if left {
+				look_delta.X = -6.
+			}
+		}
+
+		previous := world.Player.YVelocity
+		world.Step(1./60., inputs, look_delta)
+		if world.Player.YVelocity > 0. && previous <= 0. {
+			jumps++
+		}
+	}
+
+	return jumps
+}
+
+// Holding the jump key jumps only once without auto hop and on every landing with it
+func TestQuakeAutoHop(t *testing.T) {
+	if jumps := hopQuakeTestWorld(newQuakeTestWorld(false), 600, false); jumps != 1 {
+		t.Errorf("player jumped %d times without auto hop, want 1", jumps)
+	}
+	if jumps := hopQuakeTestWorld(newQuakeTestWorld(true), 600, false); jumps < 10 {
+		t.Errorf("player jumped %d times with auto hop, want on every landing", jumps)
+	}
+}
+
+// Strafing in the air while turning gets the player over the max ground speed
+func TestQuakeStrafeJumping(t *testing.T) {
+	world := newQuakeTestWorld(true)
+	ground_speed := world.Player.Speed.Current
+
+	hopQuakeTestWorld(world, 600, true)
+	if world.Player.Speed.Current < ground_speed*1.3 {
+		t.Errorf("strafe jumping got to the speed %g, want over %g", world.Player.Speed.Current, ground_speed*1.3)
+	}
+}
+
+// Friction stops the player on the ground
+func TestQuakeFriction(t *testing.T) {
+	world := newQuakeTestWorld(false)
+	world.Player.HorizontalVelocity = rl.Vector2{X: 6., Y: 0.}
+
+	stepTestWorld(world, 30, [ControlCount]bool{})
+	if world.Player.Speed.Current != 0. {
+		t.Errorf("speed is %g after 30 frames of friction, want 0", world.Player.Speed.Current)
+	}
+}
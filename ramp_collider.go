@@ -0,0 +1,198 @@
+package rlfp
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Directions in which a ramp rises
+const (
+	RampRisingPositiveX = iota
+	RampRisingNegativeX
+	RampRisingPositiveZ
+	RampRisingNegativeZ
+)
+
+// Wedge-shaped ramp collider, its sloped side goes from the bottom of the box on one side to the top of the box on the other side
+type RampCollider struct {
+	// Box around the ramp
+	Box rl.BoundingBox
+	// Direction in which the ramp rises (RampRisingPositiveX, RampRisingNegativeX, RampRisingPositiveZ, RampRisingNegativeZ)
+	Direction int
+}
+
+// Gets the box around the ramp
+//
+// #1 return: rl.BoundingBox - the box
+func (ramp RampCollider) GetBoundingBox() rl.BoundingBox {
+	return ramp.Box
+}
+
+// Checks if an axis-aligned box collides with the ramp
+//
+// #1 argument box: rl.BoundingBox - the axis-aligned box
+//
+// #1 return: bool - true if the box collides with the ramp
+func (ramp RampCollider) CheckCollisionBox(box rl.BoundingBox) bool {
+	vertices := ramp.GetCorners()
+	axes := ramp.getSeparatingAxes()
+
+	return checkCollisionBoxConvex(box, vertices[:], axes[:])
+}
+
+// Finds when a moving axis-aligned box hits the ramp
+//
+// #1 argument box: rl.BoundingBox - the moving box
+//
+// #2 argument offset: rl.Vector3 - how far the box moves
+//
+// #1 return: float32 - part of the offset the box moves before the hit, from 0 to 1
+//
+// #2 return: rl.Vector3 - normal of the hit side
+//
+// #3 return: bool - false if the box doesn't hit the ramp or is already inside it
+func (ramp RampCollider) SweepBox(box rl.BoundingBox, offset rl.Vector3) (float32, rl.Vector3, bool) {
+	vertices := ramp.GetCorners()
+	axes := ramp.getSeparatingAxes()
+
+	return sweepBoxConvex(box, offset, vertices[:], axes[:])
+}
+
+// Finds where a ray hits the ramp
+//
+// #1 argument ray: rl.Ray - the ray
+//
+// #1 return: rl.RayCollision - the closest collision in front of the ray
+func (ramp RampCollider) GetRayCollision(ray rl.Ray) rl.RayCollision {
+	closest := rl.RayCollision{Hit: false, Distance: 0., Point: rl.Vector3{X: 0., Y: 0., Z: 0.}, Normal: rl.Vector3{X: 0., Y: 0., Z: 0.}}
+
+	for _, triangle := range ramp.GetTriangles() {
+		collision := rl.GetRayCollisionTriangle(ray, triangle[0], triangle[1], triangle[2])
+		if collision.Hit && (!closest.Hit || collision.Distance < closest.Distance) {
+			closest = collision
+		}
+	}
+
+	return closest
+}
+
+// Gets the corners of the ramp
+//
+// #1 return: [6]rl.Vector3 - the four bottom corners, then the two top corners on the high side
+func (ramp RampCollider) GetCorners() [6]rl.Vector3 {
+	low, high := ramp.Box.Min, ramp.Box.Max
+
+	corners := [6]rl.Vector3{
+		{X: low.X, Y: low.Y, Z: low.Z},
+		{X: high.X, Y: low.Y, Z: low.Z},
+		{X: high.X, Y: low.Y, Z: high.Z},
+		{X: low.X, Y: low.Y, Z: high.Z},
+	}
+	switch ramp.Direction {
+	case RampRisingPositiveX:
+		corners[4] = rl.Vector3{X: high.X, Y: high.Y, Z: low.Z}
+		corners[5] = rl.Vector3{X: high.X, Y: high.Y, Z: high.Z}
+	case RampRisingNegativeX:
+		corners[4] = rl.Vector3{X: low.X, Y: high.Y, Z: low.Z}
+		corners[5] = rl.Vector3{X: low.X, Y: high.Y, Z: high.Z}
+	case RampRisingPositiveZ:
+		corners[4] = rl.Vector3{X: low.X, Y: high.Y, Z: high.Z}
+		corners[5] = rl.Vector3{X: high.X, Y: high.Y, Z: high.Z}
+	default:
+		corners[4] = rl.Vector3{X: low.X, Y: high.Y, Z: low.Z}
+		corners[5] = rl.Vector3{X: high.X, Y: high.Y, Z: low.Z}
+	}
+
+	return corners
+}
+
+// Gets the triangles of the surface of the ramp
+//
+// #1 return: [8][3]rl.Vector3 - the triangles
+func (ramp RampCollider) GetTriangles() [8][3]rl.Vector3 {
+	corners := ramp.GetCorners()
+	under_top, low_side := ramp.getBottomSides()
+
+	return [8][3]rl.Vector3{
+		// Bottom
+		{corners[0], corners[1], corners[2]},
+		{corners[0], corners[2], corners[3]},
+		// Slope
+		{low_side[0], low_side[1], corners[5]},
+		{low_side[0], corners[5], corners[4]},
+		// High side
+		{under_top[0], under_top[1], corners[5]},
+		{under_top[0], corners[5], corners[4]},
+		// Triangular sides
+		{low_side[0], under_top[0], corners[4]},
+		{low_side[1], under_top[1], corners[5]},
+	}
+}
+
+// Gets the bottom corners of the ramp split by the side they are on
+//
+// #1 return: [2]rl.Vector3 - the corners under the top edge, in the order of the top corners
+//
+// #2 return: [2]rl.Vector3 - the corners on the low side, in the order of the top corners
+func (ramp RampCollider) getBottomSides() ([2]rl.Vector3, [2]rl.Vector3) {
+	corners := ramp.GetCorners()
+
+	switch ramp.Direction {
+	case RampRisingPositiveX:
+		return [2]rl.Vector3{corners[1], corners[2]}, [2]rl.Vector3{corners[0], corners[3]}
+	case RampRisingNegativeX:
+		return [2]rl.Vector3{corners[0], corners[3]}, [2]rl.Vector3{corners[1], corners[2]}
+	case RampRisingPositiveZ:
+		return [2]rl.Vector3{corners[3], corners[2]}, [2]rl.Vector3{corners[0], corners[1]}
+	default:
+		return [2]rl.Vector3{corners[0], corners[1]}, [2]rl.Vector3{corners[3], corners[2]}
+	}
+}
+
+// Gets the normal of the sloped side of the ramp
+//
+// #1 return: rl.Vector3 - the normal pointing up and away from the ramp
+func (ramp RampCollider) GetSlopeNormal() rl.Vector3 {
+	width := ramp.Box.Max.X - ramp.Box.Min.X
+	if ramp.Direction == RampRisingPositiveZ || ramp.Direction == RampRisingNegativeZ {
+		width = ramp.Box.Max.Z - ramp.Box.Min.Z
+	}
+	height := ramp.Box.Max.Y - ramp.Box.Min.Y
+
+	// The slope rises by height over width, its normal leans against the rising direction
+	switch ramp.Direction {
+	case RampRisingPositiveX:
+		return rl.Vector3Normalize(rl.Vector3{X: -height, Y: width, Z: 0.})
+	case RampRisingNegativeX:
+		return rl.Vector3Normalize(rl.Vector3{X: height, Y: width, Z: 0.})
+	case RampRisingPositiveZ:
+		return rl.Vector3Normalize(rl.Vector3{X: 0., Y: width, Z: -height})
+	default:
+		return rl.Vector3Normalize(rl.Vector3{X: 0., Y: width, Z: height})
+	}
+}
+
+// Gets the axes which can separate an axis-aligned box and the ramp, the other edges of the ramp don't give new axes
+//
+// #1 return: [4]rl.Vector3 - the world axes and the normal of the slope
+func (ramp RampCollider) getSeparatingAxes() [4]rl.Vector3 {
+	return [4]rl.Vector3{{X: 1., Y: 0., Z: 0.}, {X: 0., Y: 1., Z: 0.}, {X: 0., Y: 0., Z: 1.}, ramp.GetSlopeNormal()}
+}
+
+// Draws the edges of a ramp
+//
+// #1 argument ramp: RampCollider - the ramp
+//
+// #2 argument color: rl.Color - color of the edges
+func DrawRamp(ramp RampCollider, color rl.Color) {
+	corners := ramp.GetCorners()
+	under_top, low_side := ramp.getBottomSides()
+
+	for i := 0; i < 4; i++ {
+		rl.DrawLine3D(corners[i], corners[(i+1)%4], color)
+	}
+	for i := 0; i < 2; i++ {
+		rl.DrawLine3D(under_top[i], corners[4+i], color)
+		rl.DrawLine3D(low_side[i], corners[4+i], color)
+	}
+	rl.DrawLine3D(corners[4], corners[5], color)
+}
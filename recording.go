@@ -0,0 +1,582 @@
+package rlfp
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Version of the recording file format written by Recording.WriteTo
+// A new version changes what is simulated, so recordings of other versions can't be replayed the same way and ReadRecording rejects them
+const RecordingVersion = 1
+
+// First bytes of every recording file
+var recordingMagic = [4]byte{'R', 'L', 'F', 'R'}
+
+// Used for recording the frames fed into world.Advance and replaying them
+type Recording struct {
+	// State of the world when the recording started
+	Start RecordingStart
+	// Recorded frames
+	Frames []RecordedFrame
+}
+
+// State of the world when a recording started, restored before replaying
+type RecordingStart struct {
+	Position                  rl.Vector3
+	BoundingBox               rl.BoundingBox
+	Rotation                  rl.Vector2
+	IsCrouching               bool
+	YVelocity                 float32
+	HorizontalVelocity        rl.Vector2
+	Speed                     float32
+	LastDirectionalKeyPressed int32
+	WasJumpHeld               bool
+	CoyoteTimer               float32
+	JumpBufferTimer           float32
+	IsJumping                 bool
+	AlreadyInteracted         bool
+	AlreadySetInteractStates  bool
+	FrameTime                 float32
+	LastFrameTime             float32
+	Accumulator               float32
+	PendingInputs             [ControlCount]bool
+	// Settings of world.FixedTimestep, they change how the frames are simulated
+	FixedTimestep bool
+	TickRate      float32
+	MaxSubsteps   int32
+	// Name of the built-in movement controller of the player, empty for other controllers, which are kept when replaying
+	Movement string
+	// Triggered and Triggering states of every trigger box
+	TriggerStates []uint8
+	// Interacted and Interacting states of every interactable box
+	InteractStates []uint8
+	// Hold and cooldown times of every interactable box
+	InteractTimes []RecordedInteractTimes
+	// State of every bounding box, kinematic and dynamic boxes move during the recording
+	BoxStates []RecordedBoxState
+}
+
+// Timers of an interactable box when a recording started
+type RecordedInteractTimes struct {
+	// How long the interact key has been held, see InteractableBox.HoldTime
+	HoldTime float32
+	// Time left until the box can be used again, see InteractableBox.CooldownTime
+	CooldownTime float32
+}
+
+// State of a bounding box when a recording started
+type RecordedBoxState struct {
+	// Position of the box
+	BoundingBox rl.BoundingBox
+	// Index of the waypoint of the box's path the box is moving to
+	Target int32
+	// If the box is going back along its path
+	Reverse bool
+	// Time left until the box leaves the waypoint it's waiting at
+	PauseTime float32
+	// Velocity of the box
+	Velocity rl.Vector3
+	// If the dynamic box lies still
+	Resting bool
+}
+
+// One frame fed into world.Advance
+type RecordedFrame struct {
+	FrameTime float32
+	InputFrame
+	// Checksum of the player's position, the trigger and interact states and the interact timers after the frame
+	Checksum uint32
+}
+
+// Returned by world.Replay when the replayed frames don't match the recording
+type ReplayError struct {
+	// Index of the first frame that diverged
+	Frame int
+	// Checksum stored in the recording
+	Expected uint32
+	// Checksum of the replayed frame
+	Actual uint32
+	// Player's position after the replayed frame
+	Position rl.Vector3
+}
+
+// Describes where the replay diverged
+//
+// #1 return: string - the error message
+func (err *ReplayError) Error() string {
+	return fmt.Sprintf("rlfp: replay diverged at frame %d (checksum %08x, expected %08x), player position {%g %g %g}",
+		err.Frame, err.Actual, err.Expected, err.Position.X, err.Position.Y, err.Position.Z)
+}
+
+// Fixed size part of the start of a recording file
+type recordingHeader struct {
+	Magic                     [4]byte
+	Version                   uint16
+	Position                  rl.Vector3
+	BoundingBox               rl.BoundingBox
+	Rotation                  rl.Vector2
+	IsCrouching               bool
+	YVelocity                 float32
+	HorizontalVelocity        rl.Vector2
+	Speed                     float32
+	LastDirectionalKeyPressed int32
+	WasJumpHeld               bool
+	CoyoteTimer               float32
+	JumpBufferTimer           float32
+	IsJumping                 bool
+	AlreadyInteracted         bool
+	AlreadySetInteractStates  bool
+	FrameTime                 float32
+	LastFrameTime             float32
+	Accumulator               float32
+	PendingInputs             uint16
+	FixedTimestep             bool
+	TickRate                  float32
+	MaxSubsteps               int32
+	MovementLength            uint8
+	TriggerCount              uint32
+	InteractableCount         uint32
+	BoundingBoxCount          uint32
+	FrameCount                uint32
+}
+
+// Encoded frame of a recording file
+type recordedFrameData struct {
+	FrameTime float32
+	Inputs    uint16
+	LookDelta rl.Vector2
+	Checksum  uint32
+}
+
+// Starts recording every frame passed to world.Advance into world.Recording
+func (world *World) StartRecording() {
+	world.Recording = &Recording{
+		Start: RecordingStart{
+			Position:                  world.Player.Position,
+			BoundingBox:               world.Player.BoundingBox,
+			Rotation:                  world.Player.Rotation,
+			IsCrouching:               world.Player.IsCrouching,
+			YVelocity:                 world.Player.YVelocity,
+			HorizontalVelocity:        world.Player.HorizontalVelocity,
+			Speed:                     world.Player.Speed.Current,
+			LastDirectionalKeyPressed: world.Player.LastDirectionalKeyPressed,
+			WasJumpHeld:               world.Player.WasJumpHeld,
+			CoyoteTimer:               world.Player.CoyoteTimer,
+			JumpBufferTimer:           world.Player.JumpBufferTimer,
+			IsJumping:                 world.Player.IsJumping,
+			AlreadyInteracted:         world.Player.AlreadyInteracted,
+			AlreadySetInteractStates:  world.AlreadySetInteractStates,
+			FrameTime:                 world.FrameTime,
+			LastFrameTime:             world.LastFrameTime,
+			Accumulator:               world.FixedTimestep.Accumulator,
+			PendingInputs:             world.FixedTimestep.PendingInputs,
+			FixedTimestep:             world.FixedTimestep.Enabled,
+			TickRate:                  world.FixedTimestep.TickRate,
+			MaxSubsteps:               world.FixedTimestep.MaxSubsteps,
+			Movement:                  getMovementControllerName(world.Player.Movement),
+			TriggerStates:             make([]uint8, len(world.TriggerBoxes)),
+			InteractStates:            make([]uint8, len(world.InteractableBoxes)),
+			InteractTimes:             make([]RecordedInteractTimes, len(world.InteractableBoxes)),
+			BoxStates:                 make([]RecordedBoxState, len(world.BoundingBoxes)),
+		},
+		Frames: []RecordedFrame{},
+	}
+
+	for i := range world.TriggerBoxes {
+		world.Recording.Start.TriggerStates[i] = packStates(world.TriggerBoxes[i].Triggered, world.TriggerBoxes[i].Triggering)
+	}
+	for i := range world.InteractableBoxes {
+		world.Recording.Start.InteractStates[i] = packStates(world.InteractableBoxes[i].Interacted, world.InteractableBoxes[i].Interacting)
+		world.Recording.Start.InteractTimes[i] = RecordedInteractTimes{
+			HoldTime:     world.InteractableBoxes[i].HoldTime,
+			CooldownTime: world.InteractableBoxes[i].CooldownTime,
+		}
+	}
+	for i := range world.BoundingBoxes {
+		world.Recording.Start.BoxStates[i] = RecordedBoxState{
+			BoundingBox: world.BoundingBoxes[i].BoundingBox,
+			Target:      int32(world.BoundingBoxes[i].Path.Target),
+			Reverse:     world.BoundingBoxes[i].Path.Reverse,
+			PauseTime:   world.BoundingBoxes[i].Path.PauseTime,
+			Velocity:    world.BoundingBoxes[i].Velocity,
+			Resting:     world.BoundingBoxes[i].Resting,
+		}
+	}
+}
+
+// Stops recording
+//
+// #1 return: *Recording - the finished recording
+func (world *World) StopRecording() *Recording {
+	recording := world.Recording
+	world.Recording = nil
+
+	return recording
+}
+
+// Adds a frame to the recording, called by world.Advance
+//
+// #1 argument frame_time: float32 - time of the frame passed to world.Advance
+//
+// #2 argument inputs: [ControlCount]bool - inputs passed to world.Advance
+//
+// #3 argument mouse_delta: rl.Vector2 - mouse movement passed to world.Advance
+func (world *World) recordFrame(frame_time float32, inputs [ControlCount]bool, mouse_delta rl.Vector2) {
+	world.Recording.Frames = append(world.Recording.Frames, RecordedFrame{
+		FrameTime:  frame_time,
+		InputFrame: InputFrame{Inputs: inputs, LookDelta: mouse_delta},
+		Checksum:   world.GetChecksum(),
+	})
+}
+
+// Gets a checksum of the player's position, the states of the trigger and interactable boxes and the timers of the interactable boxes
+//
+// #1 return: uint32 - FNV-1a hash of the state
+func (world *World) GetChecksum() uint32 {
+	hash := fnv.New32a()
+	data := make([]byte, 12)
+	binary.LittleEndian.PutUint32(data[0:], math.Float32bits(world.Player.Position.X))
+	binary.LittleEndian.PutUint32(data[4:], math.Float32bits(world.Player.Position.Y))
+	binary.LittleEndian.PutUint32(data[8:], math.Float32bits(world.Player.Position.Z))
+	hash.Write(data)
+
+	for i := range world.TriggerBoxes {
+		hash.Write([]byte{packStates(world.TriggerBoxes[i].Triggered, world.TriggerBoxes[i].Triggering)})
+	}
+	for i := range world.InteractableBoxes {
+		hash.Write([]byte{packStates(world.InteractableBoxes[i].Interacted, world.InteractableBoxes[i].Interacting)})
+		binary.LittleEndian.PutUint32(data[0:], math.Float32bits(world.InteractableBoxes[i].HoldTime))
+		binary.LittleEndian.PutUint32(data[4:], math.Float32bits(world.InteractableBoxes[i].CooldownTime))
+		hash.Write(data[:8])
+	}
+
+	return hash.Sum32()
+}
+
+// Packs a one frame state and a staying state of a box into a byte
+//
+// #1 argument once: bool - the one frame state (Triggered, Interacted)
+//
+// #2 argument staying: bool - the staying state (Triggering, Interacting)
+//
+// #1 return: uint8 - bit 0 is once, bit 1 is staying
+func packStates(once, staying bool) uint8 {
+	states := uint8(0)
+	if once {
+		states |= 1
+	}
+	if staying {
+		states |= 2
+	}
+
+	return states
+}
+
+// Replays a recording from its start state and verifies every frame against the recorded checksums
+// The world has to contain the same boxes as when the recording was made, the fixed timestep settings and a built-in
+// movement controller are restored, other movement controllers are kept
+//
+// #1 argument recording: *Recording - the recording to replay
+//
+// #1 return: error - *ReplayError when the replay diverges from the recording
+func (world *World) Replay(recording *Recording) error {
+	if len(recording.Start.TriggerStates) != len(world.TriggerBoxes) ||
+		len(recording.Start.InteractStates) != len(world.InteractableBoxes) ||
+		len(recording.Start.InteractTimes) != len(world.InteractableBoxes) ||
+		len(recording.Start.BoxStates) != len(world.BoundingBoxes) {
+
+		return errors.New("rlfp: the recording was made in a world with different boxes")
+	}
+
+	// Restore the state of the world when the recording started
+	world.Player.New(recording.Start.Position, recording.Start.Rotation, recording.Start.IsCrouching)
+	// The bounding box calculated from the position can be rounded differently, a player carried by kinematic boxes isn't on exact positions
+	world.Player.BoundingBox = recording.Start.BoundingBox
+	world.Player.YVelocity = recording.Start.YVelocity
+	world.Player.HorizontalVelocity = recording.Start.HorizontalVelocity
+	world.Player.Speed.Current = recording.Start.Speed
+	world.Player.LastDirectionalKeyPressed = recording.Start.LastDirectionalKeyPressed
+	world.Player.WasJumpHeld = recording.Start.WasJumpHeld
+	world.Player.CoyoteTimer = recording.Start.CoyoteTimer
+	world.Player.JumpBufferTimer = recording.Start.JumpBufferTimer
+	world.Player.IsJumping = recording.Start.IsJumping
+	world.Player.AlreadyInteracted = recording.Start.AlreadyInteracted
+	world.AlreadySetInteractStates = recording.Start.AlreadySetInteractStates
+	world.FrameTime = recording.Start.FrameTime
+	world.LastFrameTime = recording.Start.LastFrameTime
+	if movement := newMovementController(recording.Start.Movement); movement != nil {
+		world.Player.Movement = movement
+	}
+	world.ResetInterpolation()
+	world.FixedTimestep.Enabled = recording.Start.FixedTimestep
+	world.FixedTimestep.TickRate = recording.Start.TickRate
+	world.FixedTimestep.MaxSubsteps = recording.Start.MaxSubsteps
+	world.FixedTimestep.Accumulator = recording.Start.Accumulator
+	world.FixedTimestep.PendingInputs = recording.Start.PendingInputs
+	for i := range world.TriggerBoxes {
+		world.TriggerBoxes[i].Triggered = recording.Start.TriggerStates[i]&1 != 0
+		world.TriggerBoxes[i].Triggering = recording.Start.TriggerStates[i]&2 != 0
+	}
+	for i := range world.InteractableBoxes {
+		world.InteractableBoxes[i].Interacted = recording.Start.InteractStates[i]&1 != 0
+		world.InteractableBoxes[i].Interacting = recording.Start.InteractStates[i]&2 != 0
+		world.InteractableBoxes[i].HoldTime = recording.Start.InteractTimes[i].HoldTime
+		world.InteractableBoxes[i].CooldownTime = recording.Start.InteractTimes[i].CooldownTime
+	}
+	for i := range world.BoundingBoxes {
+		state := &recording.Start.BoxStates[i]
+		world.BoundingBoxes[i].Oriented = world.BoundingBoxes[i].Oriented.Move(rl.Vector3Subtract(state.BoundingBox.Min, world.BoundingBoxes[i].BoundingBox.Min))
+		world.BoundingBoxes[i].BoundingBox = state.BoundingBox
+		world.BoundingBoxes[i].Path.Target = int(state.Target)
+		world.BoundingBoxes[i].Path.Reverse = state.Reverse
+		world.BoundingBoxes[i].Path.PauseTime = state.PauseTime
+		world.BoundingBoxes[i].Velocity = state.Velocity
+		world.BoundingBoxes[i].Resting = state.Resting
+	}
+	world.RebuildSpatialGrids()
+
+	// Don't record the replay into an active recording
+	active_recording := world.Recording
+	world.Recording = nil
+	defer func() {
+		world.Recording = active_recording
+	}()
+
+	for i := range recording.Frames {
+		world.Advance(recording.Frames[i].FrameTime, recording.Frames[i].Inputs, recording.Frames[i].LookDelta)
+
+		if checksum := world.GetChecksum(); checksum != recording.Frames[i].Checksum {
+			return &ReplayError{
+				Frame:    i,
+				Expected: recording.Frames[i].Checksum,
+				Actual:   checksum,
+				Position: world.Player.Position,
+			}
+		}
+	}
+
+	return nil
+}
+
+// Writes the recording in a compact little endian binary format
+//
+// #1 argument writer: io.Writer - where the recording is written
+//
+// #1 return: int64 - number of bytes written
+//
+// #2 return: error - error of the writer
+func (recording *Recording) WriteTo(writer io.Writer) (int64, error) {
+	counter := &countingWriter{writer: writer}
+
+	header := recordingHeader{
+		Magic:                     recordingMagic,
+		Version:                   RecordingVersion,
+		Position:                  recording.Start.Position,
+		BoundingBox:               recording.Start.BoundingBox,
+		Rotation:                  recording.Start.Rotation,
+		IsCrouching:               recording.Start.IsCrouching,
+		YVelocity:                 recording.Start.YVelocity,
+		HorizontalVelocity:        recording.Start.HorizontalVelocity,
+		Speed:                     recording.Start.Speed,
+		LastDirectionalKeyPressed: recording.Start.LastDirectionalKeyPressed,
+		WasJumpHeld:               recording.Start.WasJumpHeld,
+		CoyoteTimer:               recording.Start.CoyoteTimer,
+		JumpBufferTimer:           recording.Start.JumpBufferTimer,
+		IsJumping:                 recording.Start.IsJumping,
+		AlreadyInteracted:         recording.Start.AlreadyInteracted,
+		AlreadySetInteractStates:  recording.Start.AlreadySetInteractStates,
+		FrameTime:                 recording.Start.FrameTime,
+		LastFrameTime:             recording.Start.LastFrameTime,
+		Accumulator:               recording.Start.Accumulator,
+		PendingInputs:             encodeInputs(recording.Start.PendingInputs),
+		FixedTimestep:             recording.Start.FixedTimestep,
+		TickRate:                  recording.Start.TickRate,
+		MaxSubsteps:               recording.Start.MaxSubsteps,
+		MovementLength:            uint8(len(recording.Start.Movement)),
+		TriggerCount:              uint32(len(recording.Start.TriggerStates)),
+		InteractableCount:         uint32(len(recording.Start.InteractStates)),
+		BoundingBoxCount:          uint32(len(recording.Start.BoxStates)),
+		FrameCount:                uint32(len(recording.Frames)),
+	}
+	if err := binary.Write(counter, binary.LittleEndian, &header); err != nil {
+		return counter.count, err
+	}
+	if _, err := counter.Write([]byte(recording.Start.Movement[:header.MovementLength])); err != nil {
+		return counter.count, err
+	}
+	if _, err := counter.Write(recording.Start.TriggerStates); err != nil {
+		return counter.count, err
+	}
+	if _, err := counter.Write(recording.Start.InteractStates); err != nil {
+		return counter.count, err
+	}
+	if err := binary.Write(counter, binary.LittleEndian, recording.Start.InteractTimes); err != nil {
+		return counter.count, err
+	}
+	if err := binary.Write(counter, binary.LittleEndian, recording.Start.BoxStates); err != nil {
+		return counter.count, err
+	}
+
+	frames := make([]recordedFrameData, len(recording.Frames))
+	for i := range recording.Frames {
+		frames[i] = recordedFrameData{
+			FrameTime: recording.Frames[i].FrameTime,
+			Inputs:    encodeInputs(recording.Frames[i].Inputs),
+			LookDelta: recording.Frames[i].LookDelta,
+			Checksum:  recording.Frames[i].Checksum,
+		}
+	}
+	err := binary.Write(counter, binary.LittleEndian, frames)
+
+	return counter.count, err
+}
+
+// Reads a recording written by Recording.WriteTo
+//
+// #1 argument reader: io.Reader - where the recording is read from
+//
+// #1 return: *Recording - the read recording
+//
+// #2 return: error - if the data is not a valid recording
+func ReadRecording(reader io.Reader) (*Recording, error) {
+	header := recordingHeader{}
+	if err := binary.Read(reader, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("rlfp: reading recording header: %w", err)
+	}
+	if header.Magic != recordingMagic {
+		return nil, errors.New("rlfp: not a recording file")
+	}
+	if header.Version != RecordingVersion {
+		return nil, fmt.Errorf("rlfp: unsupported recording version %d", header.Version)
+	}
+	if !(header.TickRate > 0.) || math.IsInf(float64(header.TickRate), 1) || header.MaxSubsteps < 0 {
+		return nil, fmt.Errorf("rlfp: invalid recording tick rate %g or max substeps %d", header.TickRate, header.MaxSubsteps)
+	}
+
+	recording := &Recording{
+		Start: RecordingStart{
+			Position:                  header.Position,
+			BoundingBox:               header.BoundingBox,
+			Rotation:                  header.Rotation,
+			IsCrouching:               header.IsCrouching,
+			YVelocity:                 header.YVelocity,
+			HorizontalVelocity:        header.HorizontalVelocity,
+			Speed:                     header.Speed,
+			LastDirectionalKeyPressed: header.LastDirectionalKeyPressed,
+			WasJumpHeld:               header.WasJumpHeld,
+			CoyoteTimer:               header.CoyoteTimer,
+			JumpBufferTimer:           header.JumpBufferTimer,
+			IsJumping:                 header.IsJumping,
+			AlreadyInteracted:         header.AlreadyInteracted,
+			AlreadySetInteractStates:  header.AlreadySetInteractStates,
+			FrameTime:                 header.FrameTime,
+			LastFrameTime:             header.LastFrameTime,
+			Accumulator:               header.Accumulator,
+			PendingInputs:             decodeInputs(header.PendingInputs),
+			FixedTimestep:             header.FixedTimestep,
+			TickRate:                  header.TickRate,
+			MaxSubsteps:               header.MaxSubsteps,
+		},
+	}
+
+	movement, err := readRecordingBytes(reader, uint32(header.MovementLength))
+	if err != nil {
+		return nil, fmt.Errorf("rlfp: reading recording movement controller: %w", err)
+	}
+	recording.Start.Movement = string(movement)
+
+	// The counts aren't trusted, the slices only grow with the data that is really there
+	if recording.Start.TriggerStates, err = readRecordingBytes(reader, header.TriggerCount); err != nil {
+		return nil, fmt.Errorf("rlfp: reading recording trigger states: %w", err)
+	}
+	if recording.Start.InteractStates, err = readRecordingBytes(reader, header.InteractableCount); err != nil {
+		return nil, fmt.Errorf("rlfp: reading recording interact states: %w", err)
+	}
+
+	recording.Start.InteractTimes = []RecordedInteractTimes{}
+	interact_times := RecordedInteractTimes{}
+	for i := uint32(0); i < header.InteractableCount; i++ {
+		if err := binary.Read(reader, binary.LittleEndian, &interact_times); err != nil {
+			return nil, fmt.Errorf("rlfp: reading recording interact times %d: %w", i, err)
+		}
+
+		recording.Start.InteractTimes = append(recording.Start.InteractTimes, interact_times)
+	}
+
+	recording.Start.BoxStates = []RecordedBoxState{}
+	box_state := RecordedBoxState{}
+	for i := uint32(0); i < header.BoundingBoxCount; i++ {
+		if err := binary.Read(reader, binary.LittleEndian, &box_state); err != nil {
+			return nil, fmt.Errorf("rlfp: reading recording box state %d: %w", i, err)
+		}
+
+		recording.Start.BoxStates = append(recording.Start.BoxStates, box_state)
+	}
+
+	recording.Frames = []RecordedFrame{}
+	frame := recordedFrameData{}
+	for i := uint32(0); i < header.FrameCount; i++ {
+		if err := binary.Read(reader, binary.LittleEndian, &frame); err != nil {
+			return nil, fmt.Errorf("rlfp: reading recording frame %d: %w", i, err)
+		}
+		// world.Advance records clamped frame times, a negative, infinite or NaN one means the file is corrupted
+		if !(frame.FrameTime >= 0.) || math.IsInf(float64(frame.FrameTime), 1) {
+			return nil, fmt.Errorf("rlfp: invalid frame time %g of recording frame %d", frame.FrameTime, i)
+		}
+
+		recording.Frames = append(recording.Frames, RecordedFrame{
+			FrameTime:  frame.FrameTime,
+			InputFrame: InputFrame{Inputs: decodeInputs(frame.Inputs), LookDelta: frame.LookDelta},
+			Checksum:   frame.Checksum,
+		})
+	}
+
+	return recording, nil
+}
+
+// Reads bytes of a recording without allocating more than what the reader has
+//
+// #1 argument reader: io.Reader - where the bytes are read from
+//
+// #2 argument count: uint32 - number of bytes to read
+//
+// #1 return: []byte - the read bytes
+//
+// #2 return: error - io.ErrUnexpectedEOF if the reader has less bytes
+func readRecordingBytes(reader io.Reader, count uint32) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, int64(count)))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != int(count) {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	return data, nil
+}
+
+// Counts the bytes written to a writer
+type countingWriter struct {
+	writer io.Writer
+	count  int64
+}
+
+// Writes to the underlying writer and counts the written bytes
+//
+// #1 argument data: []byte - data to write
+//
+// #1 return: int - number of bytes written
+//
+// #2 return: error - error of the underlying writer
+func (counter *countingWriter) Write(data []byte) (int, error) {
+	n, err := counter.writer.Write(data)
+	counter.count += int64(n)
+
+	return n, err
+}
@@ -0,0 +1,199 @@
+package rlfp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Records frames of a world with variable frame times
+//
+// #1 argument world: *World - the recorded world
+//
+// #2 argument frames: int - number of recorded frames
+//
+// #1 return: *Recording - the recording
+func recordTestFrames(world *World, frames int) *Recording {
+	world.StartRecording()
+	for i := 0; i < frames; i++ {
+		inputs := [ControlCount]bool{}
+		inputs[ControlForward] = i%50 < 30
+		inputs[ControlJump] = i%70 == 3
+		inputs[ControlInteract] = i%40 < 20
+		world.Advance(1./float32(30+i%40), inputs, rl.Vector2{X: float32(i % 3), Y: 0.})
+	}
+
+	return world.StopRecording()
+}
+
+// Writes a recording and reads it back
+//
+// #1 argument t: *testing.T - the test
+//
+// #2 argument recording: *Recording - the recording
+//
+// #1 return: *Recording - the read recording
+func rereadTestRecording(t *testing.T, recording *Recording) *Recording {
+	t.Helper()
+
+	buffer := &bytes.Buffer{}
+	written, err := recording.WriteTo(buffer)
+	if err != nil || written != int64(buffer.Len()) {
+		t.Fatalf("wrote %d bytes of %d with error %v", written, buffer.Len(), err)
+	}
+
+	read, err := ReadRecording(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return read
+}
+
+// A recording replays from its start state and a changed frame is reported
+func TestRecordingReplay(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: -3., Y: 0., Z: -1.}, Max: rl.Vector3{X: -2., Y: .3, Z: 1.}})
+	world.AddTriggerBox(rl.BoundingBox{Min: rl.Vector3{X: -5., Y: 0., Z: -1.}, Max: rl.Vector3{X: -4., Y: 1., Z: 1.}})
+	world.AddInteractableBox(rl.BoundingBox{Min: rl.Vector3{X: -5., Y: 0., Z: -1.}, Max: rl.Vector3{X: -4., Y: 1., Z: 1.}})
+
+	recording := rereadTestRecording(t, recordTestFrames(world, 200))
+	if len(recording.Frames) != 200 {
+		t.Fatalf("read %d frames, want 200", len(recording.Frames))
+	}
+	if err := world.Replay(recording); err != nil {
+		t.Fatal(err)
+	}
+
+	recording.Frames[100].Checksum++
+	replay_error := &ReplayError{}
+	if err := world.Replay(recording); !errors.As(err, &replay_error) || replay_error.Frame != 100 {
+		t.Errorf("replay of a changed frame returned %v, want a divergence at frame 100", err)
+	}
+}
+
+// A recording made with different boxes isn't replayed
+func TestReplayWithDifferentBoxes(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	recording := recordTestFrames(world, 10)
+
+	world.AddTriggerBox(rl.BoundingBox{Min: rl.Vector3{X: -5., Y: 0., Z: -1.}, Max: rl.Vector3{X: -4., Y: 1., Z: 1.}})
+	if err := world.Replay(recording); err == nil {
+		t.Error("recording of a world without the trigger box was replayed")
+	}
+}
+
+// Files that aren't recordings of the current version are rejected without trusting their counts
+func TestReadRecordingRejectsInvalidFiles(t *testing.T) {
+	headers := map[string]recordingHeader{
+		"magic":         {Magic: [4]byte{'R', 'L', 'F', 'S'}, Version: RecordingVersion, TickRate: 60.},
+		"other version": {Magic: recordingMagic, Version: RecordingVersion + 1, TickRate: 60.},
+		"tick rate":     {Magic: recordingMagic, Version: RecordingVersion, TickRate: -60.},
+		"trigger count": {Magic: recordingMagic, Version: RecordingVersion, TickRate: 60., TriggerCount: 0xffffffff},
+		"box count":     {Magic: recordingMagic, Version: RecordingVersion, TickRate: 60., BoundingBoxCount: 0xffffffff},
+		"frame count":   {Magic: recordingMagic, Version: RecordingVersion, TickRate: 60., FrameCount: 0xffffffff},
+	}
+
+	for name, header := range headers {
+		buffer := &bytes.Buffer{}
+		binary.Write(buffer, binary.LittleEndian, &header)
+		if _, err := ReadRecording(buffer); err == nil {
+			t.Errorf("%s: invalid recording was read", name)
+		}
+	}
+
+	if _, err := ReadRecording(bytes.NewReader(recordingMagic[:])); err == nil {
+		t.Error("truncated header was read")
+	}
+
+	for _, frame_time := range []float32{-1., float32(math.NaN()), float32(math.Inf(1))} {
+		recording := &Recording{Start: RecordingStart{TickRate: 60.}, Frames: []RecordedFrame{{FrameTime: frame_time}}}
+		buffer := &bytes.Buffer{}
+		recording.WriteTo(buffer)
+		if _, err := ReadRecording(buffer); err == nil {
+			t.Errorf("recording with the frame time %g was read", frame_time)
+		}
+	}
+}
+
+// The fixed timestep settings and the movement controller of the recorded world are restored when replaying
+func TestReplayRestoresSettings(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	world.FixedTimestep.Enabled = true
+	world.FixedTimestep.TickRate = 30.
+	world.Player.Movement = &FlyController{}
+	recording := rereadTestRecording(t, recordTestFrames(world, 200))
+
+	replayed := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	if err := replayed.Replay(recording); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := replayed.Player.Movement.(*FlyController); !ok || !replayed.FixedTimestep.Enabled || replayed.FixedTimestep.TickRate != 30. {
+		t.Errorf("replayed with %T and fixed timestep %+v, want *FlyController at 30 ticks per second", replayed.Player.Movement, replayed.FixedTimestep)
+	}
+}
+
+// A recording started while an interactable box is held or cooling down replays from the same times in another world
+func TestRecordingReplayHeldBox(t *testing.T) {
+	worlds := [2]*World{newInteractTestWorld(), newInteractTestWorld()}
+	for _, world := range worlds {
+		id := world.AddInteractableBox(getLookTestBox(world, 1.5, .2))
+		world.SetInteractableBoxHoldDuration(id, .3)
+		world.SetInteractableBoxCooldown(id, .5)
+	}
+	stepHoldTestWorld(worlds[0], 10, getTestInputs(ControlInteract))
+
+	worlds[0].StartRecording()
+	for i := 0; i < 60; i++ {
+		inputs := [ControlCount]bool{}
+		inputs[ControlInteract] = i%25 < 20
+		worlds[0].Advance(.016, inputs, rl.Vector2{X: 0., Y: 0.})
+	}
+
+	if err := worlds[1].Replay(rereadTestRecording(t, worlds[0].StopRecording())); err != nil {
+		t.Fatal(err)
+	}
+	box, replayed := worlds[0].InteractableBoxes[0], worlds[1].InteractableBoxes[0]
+	if replayed.HoldTime != box.HoldTime || replayed.CooldownTime != box.CooldownTime {
+		t.Errorf("replay ended with hold time %g and cooldown %g, want %g and %g", replayed.HoldTime, replayed.CooldownTime, box.HoldTime, box.CooldownTime)
+	}
+}
+
+// A recording started while a lift is moving replays in another world from the lift's position
+func TestRecordingReplayKinematicBox(t *testing.T) {
+	worlds := [2]*World{}
+	for i := range worlds {
+		worlds[i], _ = newLiftTestWorld()
+	}
+	stepTestWorld(worlds[0], 70, [ControlCount]bool{})
+
+	recording := rereadTestRecording(t, recordTestFrames(worlds[0], 200))
+	if err := worlds[1].Replay(recording); err != nil {
+		t.Fatal(err)
+	}
+	if worlds[1].BoundingBoxes[0].BoundingBox != worlds[0].BoundingBoxes[0].BoundingBox {
+		t.Errorf("replayed lift is at %v, want %v", worlds[1].BoundingBoxes[0].BoundingBox, worlds[0].BoundingBoxes[0].BoundingBox)
+	}
+}
+
+// A recording started while a rigid box is falling replays in another world from the box's position and velocity
+func TestRecordingReplayRigidBox(t *testing.T) {
+	worlds := [2]*World{}
+	for i := range worlds {
+		worlds[i] = newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+		worlds[i].AddRigidBox(rl.BoundingBox{Min: rl.Vector3{X: -4., Y: 6., Z: -.5}, Max: rl.Vector3{X: -3., Y: 7., Z: .5}}, 1.)
+	}
+	stepTestWorld(worlds[0], 20, [ControlCount]bool{})
+
+	recording := rereadTestRecording(t, recordTestFrames(worlds[0], 200))
+	if err := worlds[1].Replay(recording); err != nil {
+		t.Fatal(err)
+	}
+	if worlds[1].BoundingBoxes[0].BoundingBox != worlds[0].BoundingBoxes[0].BoundingBox {
+		t.Errorf("replayed box is at %v, want %v", worlds[1].BoundingBoxes[0].BoundingBox, worlds[0].BoundingBoxes[0].BoundingBox)
+	}
+}
@@ -0,0 +1,371 @@
+package rlfp
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"github.com/chewxy/math32"
+)
+
+// Dynamic boxes slower than this on the X and Z axis stop and start resting when they are on something
+const rigidBoxRestSpeed = .05
+
+// Axes of a vector
+const (
+	axisX = iota
+	axisY
+	axisZ
+)
+
+// Adds a dynamic rigid box to the world, it falls with world.Gravity, collides with the other boxes and the player can stand on it
+//
+// #1 argument box: rl.BoundingBox - bounding box to add
+//
+// #2 argument mass: float32 - mass of the box, used when it hits other dynamic boxes (values <= 0 are replaced by 1)
+//
+// #1 return: BoxID - stable ID of the new box
+func (world *World) AddRigidBox(box rl.BoundingBox, mass float32) BoxID {
+	if mass <= 0. {
+		mass = 1.
+	}
+
+	id := world.AddBoundingBox(box)
+	i := len(world.BoundingBoxes) - 1
+	world.BoundingBoxes[i].Dynamic = true
+	world.BoundingBoxes[i].Mass = mass
+	world.BoundingBoxes[i].Friction = 10.
+	world.dynamic_boxes = append(world.dynamic_boxes, i)
+
+	return id
+}
+
+// Updates every dynamic box, called after the kinematic boxes and before the player are updated
+func (world *World) UpdateRigidBoxes() {
+	if !world.bounding_box_grid.isInitialized() || world.bounding_box_grid.count != len(world.BoundingBoxes) {
+		world.RebuildSpatialGrids()
+	}
+
+	for _, i := range world.dynamic_boxes {
+		if world.BoundingBoxes[i].Disabled || !world.isBoundingBoxActive(i) {
+			continue
+		}
+
+		if world.BoundingBoxes[i].Resting {
+			// Start falling when the box under it was moved or removed
+			if world.isRigidBoxSupported(i) && world.getRigidBoxOverlap(i) == -1 {
+				continue
+			}
+			world.BoundingBoxes[i].Resting = false
+		}
+
+		world.UpdateRigidBox(i)
+	}
+}
+
+// Moves a dynamic box by its velocity, the box is stopped on every axis where it hits something
+//
+// #1 argument i: int - index of the bounding box
+func (world *World) UpdateRigidBox(i int) {
+	world.depenetrateRigidBox(i)
+
+	box := &world.BoundingBoxes[i]
+	box.Velocity.Y -= world.Gravity * world.FrameTime
+
+	is_riding := world.isPlayerRiding(box.BoundingBox)
+	start := box.BoundingBox.Min
+
+	// Y first, so the box lands before it slides
+	is_supported := false
+	for _, axis := range [3]int{axisY, axisX, axisZ} {
+		velocity := getAxis(box.Velocity, axis)
+		if velocity == 0. {
+			continue
+		}
+
+		distance := velocity * world.FrameTime
+		moved, hit := world.getRigidBoxMove(i, axis, distance)
+		world.moveBoundingBox(i, rl.Vector3Add(box.BoundingBox.Min, setAxis(rl.Vector3{X: 0., Y: 0., Z: 0.}, axis, moved)))
+
+		if hit == -1 && moved == distance {
+			continue
+		}
+
+		if axis == axisY && distance < 0. {
+			is_supported = true
+		}
+		world.hitRigidBox(i, hit, axis)
+	}
+
+	if is_riding {
+		world.carryPlayer(i, rl.Vector3Subtract(box.BoundingBox.Min, start))
+	}
+
+	if !is_supported {
+		return
+	}
+
+	// Slow down on the X and Z axis when lying on something
+	horizontal := rl.Vector2{X: box.Velocity.X, Y: box.Velocity.Z}
+	speed := rl.Vector2Length(horizontal)
+	slowed := speed - box.Friction*world.FrameTime
+	if slowed <= rigidBoxRestSpeed {
+		box.Velocity = rl.Vector3{X: 0., Y: 0., Z: 0.}
+		box.Resting = true
+		return
+	}
+	horizontal = rl.Vector2Scale(horizontal, slowed/speed)
+	box.Velocity.X = horizontal.X
+	box.Velocity.Z = horizontal.Y
+}
+
+// Gets how far a dynamic box can move on an axis before it hits something
+//
+// #1 argument i: int - index of the bounding box
+//
+// #2 argument axis: int - the axis (axisX, axisY, axisZ)
+//
+// #3 argument distance: float32 - how far the box wants to move
+//
+// #1 return: float32 - how far the box can move
+//
+// #2 return: int - index of the bounding box that was hit, -1 if the box hit nothing, the ground, the player or a collider
+func (world *World) getRigidBoxMove(i int, axis int, distance float32) (float32, int) {
+	box := world.BoundingBoxes[i].BoundingBox
+	area := box
+	if distance > 0. {
+		area.Max = setAxis(area.Max, axis, getAxis(area.Max, axis)+distance)
+	} else {
+		area.Min = setAxis(area.Min, axis, getAxis(area.Min, axis)+distance)
+	}
+
+	moved := distance
+	hit := -1
+	limit := func(blocker rl.BoundingBox) bool {
+		if !isOverlapping(area, blocker) {
+			return false
+		}
+
+		var allowed float32
+		if distance > 0. {
+			allowed = math32.Max(getAxis(blocker.Min, axis)-getAxis(box.Max, axis)-world.FloatPrecision, 0.)
+			if allowed >= moved {
+				return false
+			}
+		} else {
+			allowed = math32.Min(getAxis(blocker.Max, axis)-getAxis(box.Min, axis)+world.FloatPrecision, 0.)
+			if allowed <= moved {
+				return false
+			}
+		}
+		moved = allowed
+
+		return true
+	}
+
+	// Shapes other than axis-aligned boxes are swept, so the box stops on their surface and not on the box around them
+	sweep := func(collider Collider) bool {
+		time, _, ok := collider.SweepBox(box, setAxis(rl.Vector3{X: 0., Y: 0., Z: 0.}, axis, distance))
+		if !ok {
+			return false
+		}
+
+		allowed := distance*time - math32.Copysign(world.FloatPrecision, distance)
+		if math32.Abs(allowed) >= math32.Abs(moved) {
+			return false
+		}
+		if allowed*distance < 0. {
+			allowed = 0.
+		}
+		moved = allowed
+
+		return true
+	}
+
+	for _, j := range world.queryBoundingBoxes(area) {
+		if j == i || !world.isBoundingBoxActive(j) {
+			continue
+		}
+
+		if world.BoundingBoxes[j].IsOriented {
+			if sweep(world.BoundingBoxes[j].Oriented) {
+				hit = j
+			}
+		} else if limit(world.BoundingBoxes[j].BoundingBox) {
+			hit = j
+		}
+	}
+	if world.Player.getMovement().HasCollisions() && limit(world.Player.BoundingBox) {
+		hit = -1
+	}
+	for _, j := range world.queryColliders(area) {
+		if world.isColliderActive(j) && sweep(world.Colliders[j].Collider) {
+			hit = -1
+		}
+	}
+	if axis == axisY && distance < 0. && box.Min.Y+moved < world.Ground+world.FloatPrecision {
+		moved = math32.Min(world.Ground+world.FloatPrecision-box.Min.Y, 0.)
+		hit = -1
+	}
+
+	return moved, hit
+}
+
+// Stops a dynamic box on an axis, when it hit another dynamic box they move together with their combined momentum
+//
+// #1 argument i: int - index of the bounding box that moved
+//
+// #2 argument hit: int - index of the bounding box that was hit, -1 for the ground, the player, a collider or a static box
+//
+// #3 argument axis: int - the axis of the hit
+func (world *World) hitRigidBox(i int, hit int, axis int) {
+	box := &world.BoundingBoxes[i]
+
+	if hit == -1 || !world.BoundingBoxes[hit].Dynamic || axis == axisY {
+		box.Velocity = setAxis(box.Velocity, axis, 0.)
+		return
+	}
+
+	other := &world.BoundingBoxes[hit]
+	velocity := (getAxis(box.Velocity, axis)*box.Mass + getAxis(other.Velocity, axis)*other.Mass) / (box.Mass + other.Mass)
+	box.Velocity = setAxis(box.Velocity, axis, velocity)
+	other.Velocity = setAxis(other.Velocity, axis, velocity)
+	other.Resting = false
+}
+
+// Checks if a dynamic box lies on the ground, on another box or on a collider
+//
+// #1 argument i: int - index of the bounding box
+//
+// #1 return: bool - true if there is something right under the box
+func (world *World) isRigidBoxSupported(i int) bool {
+	box := world.BoundingBoxes[i].BoundingBox
+	if box.Min.Y-world.FloatPrecision*2. <= world.Ground {
+		return true
+	}
+
+	below := rl.BoundingBox{
+		Min: rl.Vector3{X: box.Min.X, Y: box.Min.Y - world.FloatPrecision*2., Z: box.Min.Z},
+		Max: rl.Vector3{X: box.Max.X, Y: box.Min.Y, Z: box.Max.Z},
+	}
+	for _, j := range world.queryBoundingBoxes(below) {
+		if j != i && world.isBoundingBoxActive(j) && world.isBoundingBoxOverlapping(j, below) {
+			return true
+		}
+	}
+
+	return world.isColliding(below)
+}
+
+// Finds a box that is overlapping a dynamic box, e.g. a kinematic box that moved into it
+//
+// #1 argument i: int - index of the bounding box
+//
+// #1 return: int - index of the overlapping bounding box, -1 if there is none
+func (world *World) getRigidBoxOverlap(i int) int {
+	box := world.BoundingBoxes[i].BoundingBox
+
+	for _, j := range world.queryBoundingBoxes(box) {
+		if j != i && world.isBoundingBoxActive(j) && world.isBoundingBoxOverlapping(j, box) {
+			return j
+		}
+	}
+
+	return -1
+}
+
+// Moves a dynamic box out of the box overlapping it on the axis that needs the shortest push
+//
+// #1 argument i: int - index of the bounding box
+func (world *World) depenetrateRigidBox(i int) {
+	j := world.getRigidBoxOverlap(i)
+	if j == -1 {
+		return
+	}
+
+	box := world.BoundingBoxes[i].BoundingBox
+	other := world.BoundingBoxes[j].BoundingBox
+
+	push := rl.Vector3{X: 0., Y: 0., Z: 0.}
+	shortest := float32(-1.)
+	for _, axis := range [3]int{axisY, axisX, axisZ} {
+		up := getAxis(other.Max, axis) + world.FloatPrecision - getAxis(box.Min, axis)
+		down := getAxis(other.Min, axis) - world.FloatPrecision - getAxis(box.Max, axis)
+
+		if shortest < 0. || up < shortest {
+			shortest = up
+			push = setAxis(rl.Vector3{X: 0., Y: 0., Z: 0.}, axis, up)
+		}
+		if -down < shortest {
+			shortest = -down
+			push = setAxis(rl.Vector3{X: 0., Y: 0., Z: 0.}, axis, down)
+		}
+	}
+
+	world.moveBoundingBox(i, rl.Vector3Add(box.Min, push))
+	if push.Y > 0. && world.BoundingBoxes[i].Velocity.Y < 0. {
+		world.BoundingBoxes[i].Velocity.Y = 0.
+	}
+}
+
+// Checks if an area overlaps the shape of a bounding box, touching the box around an oriented box doesn't count
+//
+// #1 argument i: int - index of the bounding box
+//
+// #2 argument area: rl.BoundingBox - the area
+//
+// #1 return: bool - true if the area overlaps the box and collides with its oriented box if it has one
+func (world *World) isBoundingBoxOverlapping(i int, area rl.BoundingBox) bool {
+	return isOverlapping(area, world.BoundingBoxes[i].BoundingBox) && world.isBoundingBoxColliding(i, area)
+}
+
+// Checks if two boxes overlap, touching boxes don't overlap
+//
+// #1 argument a: rl.BoundingBox - the first box
+//
+// #2 argument b: rl.BoundingBox - the second box
+//
+// #1 return: bool - true if the boxes overlap
+func isOverlapping(a rl.BoundingBox, b rl.BoundingBox) bool {
+	return a.Min.X < b.Max.X && a.Max.X > b.Min.X &&
+		a.Min.Y < b.Max.Y && a.Max.Y > b.Min.Y &&
+		a.Min.Z < b.Max.Z && a.Max.Z > b.Min.Z
+}
+
+// Gets a component of a vector
+//
+// #1 argument vector: rl.Vector3 - the vector
+//
+// #2 argument axis: int - the axis (axisX, axisY, axisZ)
+//
+// #1 return: float32 - the component
+func getAxis(vector rl.Vector3, axis int) float32 {
+	switch axis {
+	case axisX:
+		return vector.X
+	case axisY:
+		return vector.Y
+	default:
+		return vector.Z
+	}
+}
+
+// Sets a component of a vector
+//
+// #1 argument vector: rl.Vector3 - the vector
+//
+// #2 argument axis: int - the axis (axisX, axisY, axisZ)
+//
+// #3 argument value: float32 - the new value of the component
+//
+// #1 return: rl.Vector3 - the changed vector
+func setAxis(vector rl.Vector3, axis int, value float32) rl.Vector3 {
+	switch axis {
+	case axisX:
+		vector.X = value
+	case axisY:
+		vector.Y = value
+	default:
+		vector.Z = value
+	}
+
+	return vector
+}
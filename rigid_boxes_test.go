@@ -0,0 +1,83 @@
+package rlfp
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"github.com/chewxy/math32"
+)
+
+// Creates a cube with the size 1
+//
+// #1 argument x: float32 - X position of the minimum corner
+//
+// #2 argument y: float32 - Y position of the minimum corner
+//
+// #1 return: rl.BoundingBox - the cube
+func getTestCrate(x float32, y float32) rl.BoundingBox {
+	return rl.BoundingBox{Min: rl.Vector3{X: x, Y: y, Z: -.5}, Max: rl.Vector3{X: x + 1., Y: y + 1., Z: .5}}
+}
+
+// Checks if a rigid box rests at a height
+//
+// #1 argument t: *testing.T - the test
+//
+// #2 argument world: *World - the world with the box
+//
+// #3 argument id: BoxID - ID of the box
+//
+// #4 argument y: float32 - the expected Y position of the bottom of the box
+func checkTestCrateRest(t *testing.T, world *World, id BoxID, y float32) {
+	t.Helper()
+
+	box, _ := world.GetBoundingBox(id)
+	if !box.Resting || math32.Abs(box.BoundingBox.Min.Y-y) > .01 {
+		t.Errorf("box %d is at Y %g resting %t, want resting at Y %g", id, box.BoundingBox.Min.Y, box.Resting, y)
+	}
+}
+
+// Rigid boxes fall, stack on each other and fall again when their support is removed
+func TestRigidBoxesStack(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	bottom := world.AddRigidBox(getTestCrate(3., 3.), 1.)
+	top := world.AddRigidBox(getTestCrate(3.2, 6.), 1.)
+
+	stepTestWorld(world, 180, [ControlCount]bool{})
+	checkTestCrateRest(t, world, bottom, 0.)
+	checkTestCrateRest(t, world, top, 1.)
+
+	world.RemoveBoundingBox(bottom)
+	stepTestWorld(world, 120, [ControlCount]bool{})
+	checkTestCrateRest(t, world, top, 0.)
+}
+
+// The player stands on a rigid box
+func TestPlayerStandsOnRigidBox(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 3., Z: 0.})
+	crate := world.AddRigidBox(getTestCrate(-.5, 0.), 1.)
+
+	stepTestWorld(world, 120, [ControlCount]bool{})
+	checkTestCrateRest(t, world, crate, 0.)
+	if math32.Abs(world.Player.BoundingBox.Min.Y-1.) > .01 {
+		t.Errorf("player is at Y %g, want on the box at Y 1", world.Player.BoundingBox.Min.Y)
+	}
+}
+
+// Rigid boxes rest on the real shapes of oriented boxes and colliders, not on their bounding boxes
+func TestRigidBoxesOnShapes(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	world.AddOrientedBoundingBox(OrientedBox{
+		Center:      rl.Vector3{X: 5., Y: 1., Z: 0.},
+		HalfExtents: rl.Vector3{X: .5, Y: .5, Z: .5},
+		Rotation:    rl.QuaternionFromAxisAngle(rl.Vector3{X: 0., Y: 0., Z: 1.}, math32.Pi/4.),
+	})
+	world.AddCollider(SphereCollider{Center: rl.Vector3{X: -5., Y: 2., Z: 0.}, Radius: .5})
+	on_oriented := world.AddRigidBox(getTestCrate(5.6, 4.), 1.)
+	on_sphere := world.AddRigidBox(getTestCrate(-5.5, 4.), 1.)
+
+	stepTestWorld(world, 180, [ControlCount]bool{})
+	// The corner of the crate touches the rotated edge of the oriented box
+	checkTestCrateRest(t, world, on_oriented, 1.+math32.Sqrt(.5)-.6)
+	checkTestCrateRest(t, world, on_sphere, 2.5)
+}
@@ -0,0 +1,300 @@
+package rlfp
+
+import (
+	"bufio"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Version of the save format written by world.Save and world.SaveBinary
+const SaveVersion = 1
+
+// First bytes of every binary save file
+var saveMagic = [4]byte{'R', 'L', 'F', 'S'}
+
+// Save file written by world.Save as JSON and by world.SaveBinary as "RLFS" followed by a gob stream
+// When the format changes, SaveVersion is increased and saves of older versions are migrated to it when loading
+type SaveFile struct {
+	Version int
+	World   *WorldState
+}
+
+// Saved state of the world
+type WorldState struct {
+	Player                   PlayerState
+	Ground                   float32
+	Gravity                  float32
+	FrameTime                float32
+	LastFrameTime            float32
+	BoundingBoxes            []CollisionBox
+	TriggerBoxes             []TriggerBox
+	InteractableBoxes        []InteractableBox
+	FloatPrecision           float32
+	CalculationDistance      float32
+	AlreadySetInteractStates bool
+	FixedTimestep            FixedTimestep
+	NextBoxID                BoxID
+}
+
+// Saved state of the player, everything except player.Input, player.Movement is saved by its name
+type PlayerState struct {
+	Speed                     PlayerSpeeds
+	MouseSensitivity          PlayerSensitivities
+	Fovs                      PlayerFOVs
+	BoundingBox               rl.BoundingBox
+	Position                  rl.Vector3
+	OffsetNextFrame           rl.Vector3
+	Rotation                  rl.Vector2
+	Scale                     rl.Vector3
+	ConstScale                PlayerScale
+	IsCrouching               bool
+	YVelocity                 float32
+	JumpPower                 float32
+	LastDirectionalKeyPressed int32
+	InteractRange             float32
+	AlreadyInteracted         bool
+	StepHeight                float32
+	// Slices, so adding controls doesn't break older saves, controls missing in the save keep the player's current values
+	Controls           []int32
+	CurrentInputs      []bool
+	Camera             rl.Camera3D
+	PushStrength       float32
+	MaxSlopeAngle      float32
+	HorizontalVelocity rl.Vector2
+	// Name of a built-in movement controller, empty for other controllers
+	Movement        string
+	Quake           PlayerQuakeSpeeds
+	WasJumpHeld     bool
+	JumpAssists     PlayerJumpAssists
+	CoyoteTimer     float32
+	JumpBufferTimer float32
+	IsJumping       bool
+}
+
+// Saves the state of the world as JSON
+//
+// #1 argument writer: io.Writer - where the save is written
+//
+// #1 return: error - error of the writer
+func (world *World) Save(writer io.Writer) error {
+	file := world.getSaveFile()
+
+	// Ray collisions that didn't hit can have an infinite distance, which JSON can't store, they are recalculated every frame anyway
+	for i := range file.World.InteractableBoxes {
+		if !file.World.InteractableBoxes[i].RayCollision.Hit {
+			file.World.InteractableBoxes[i].RayCollision = rl.RayCollision{}
+		}
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "\t")
+
+	return encoder.Encode(file)
+}
+
+// Saves the state of the world in the compact binary format
+//
+// #1 argument writer: io.Writer - where the save is written
+//
+// #1 return: error - error of the writer
+func (world *World) SaveBinary(writer io.Writer) error {
+	if _, err := writer.Write(saveMagic[:]); err != nil {
+		return err
+	}
+
+	return gob.NewEncoder(writer).Encode(world.getSaveFile())
+}
+
+// Loads the state of the world saved by world.Save, world.Init should be called before loading
+//
+// #1 argument reader: io.Reader - where the save is read from
+//
+// #1 return: error - if the save is not valid
+func (world *World) Load(reader io.Reader) error {
+	file := SaveFile{}
+	if err := json.NewDecoder(reader).Decode(&file); err != nil {
+		return fmt.Errorf("rlfp: decoding save: %w", err)
+	}
+
+	return world.loadSaveFile(&file)
+}
+
+// Loads the state of the world saved by world.SaveBinary, world.Init should be called before loading
+//
+// #1 argument reader: io.Reader - where the save is read from
+//
+// #1 return: error - if the save is not valid
+func (world *World) LoadBinary(reader io.Reader) error {
+	buffered := bufio.NewReader(reader)
+
+	magic := [4]byte{}
+	if _, err := io.ReadFull(buffered, magic[:]); err != nil {
+		return fmt.Errorf("rlfp: reading save: %w", err)
+	}
+	if magic != saveMagic {
+		return errors.New("rlfp: not a binary save file")
+	}
+
+	file := SaveFile{}
+	if err := gob.NewDecoder(buffered).Decode(&file); err != nil {
+		return fmt.Errorf("rlfp: decoding save: %w", err)
+	}
+
+	return world.loadSaveFile(&file)
+}
+
+// Applies a save file to the world
+//
+// #1 argument file: *SaveFile - the decoded save file
+//
+// #1 return: error - if the save file has an unsupported version or no world state
+func (world *World) loadSaveFile(file *SaveFile) error {
+	if file.Version != SaveVersion {
+		return fmt.Errorf("rlfp: unsupported save version %d", file.Version)
+	}
+	if file.World == nil {
+		return errors.New("rlfp: save has no world state")
+	}
+
+	world.SetState(file.World)
+
+	return nil
+}
+
+// Gets the current save file of the world
+//
+// #1 return: *SaveFile - the save file with SaveVersion
+func (world *World) getSaveFile() *SaveFile {
+	return &SaveFile{
+		Version: SaveVersion,
+		World:   world.GetState(),
+	}
+}
+
+// Gets a copy of the whole state of the world
+//
+// #1 return: *WorldState - the state of the world
+func (world *World) GetState() *WorldState {
+	return &WorldState{
+		Player:                   world.Player.getState(),
+		Ground:                   world.Ground,
+		Gravity:                  world.Gravity,
+		FrameTime:                world.FrameTime,
+		LastFrameTime:            world.LastFrameTime,
+		BoundingBoxes:            append([]CollisionBox{}, world.BoundingBoxes...),
+		TriggerBoxes:             append([]TriggerBox{}, world.TriggerBoxes...),
+		InteractableBoxes:        append([]InteractableBox{}, world.InteractableBoxes...),
+		FloatPrecision:           world.FloatPrecision,
+		CalculationDistance:      world.CalculationDistance,
+		AlreadySetInteractStates: world.AlreadySetInteractStates,
+		FixedTimestep:            world.FixedTimestep,
+		NextBoxID:                world.NextBoxID,
+	}
+}
+
+// Restores the whole state of the world, world.Player.Input, world.Recording and world.Colliders are kept
+// world.Player.Movement is kept when the state has no built-in movement controller
+//
+// #1 argument state: *WorldState - the state to restore
+func (world *World) SetState(state *WorldState) {
+	world.Player.setState(&state.Player)
+	world.Ground = state.Ground
+	world.Gravity = state.Gravity
+	world.FrameTime = state.FrameTime
+	world.LastFrameTime = state.LastFrameTime
+	world.BoundingBoxes = append([]CollisionBox{}, state.BoundingBoxes...)
+	world.TriggerBoxes = append([]TriggerBox{}, state.TriggerBoxes...)
+	world.InteractableBoxes = append([]InteractableBox{}, state.InteractableBoxes...)
+	world.FloatPrecision = state.FloatPrecision
+	world.CalculationDistance = state.CalculationDistance
+	world.AlreadySetInteractStates = state.AlreadySetInteractStates
+	world.FixedTimestep = state.FixedTimestep
+	world.NextBoxID = state.NextBoxID
+	// Colliders aren't saved, their IDs must not be given to new boxes
+	for i := range world.Colliders {
+		if world.Colliders[i].ID > world.NextBoxID {
+			world.NextBoxID = world.Colliders[i].ID
+		}
+	}
+	world.RebuildSpatialGrids()
+}
+
+// Gets a copy of the state of the player
+//
+// #1 return: PlayerState - the state of the player
+func (player *Player) getState() PlayerState {
+	return PlayerState{
+		Speed:                     player.Speed,
+		MouseSensitivity:          player.MouseSensitivity,
+		Fovs:                      player.Fovs,
+		BoundingBox:               player.BoundingBox,
+		Position:                  player.Position,
+		OffsetNextFrame:           player.OffsetNextFrame,
+		Rotation:                  player.Rotation,
+		Scale:                     player.Scale,
+		ConstScale:                player.ConstScale,
+		IsCrouching:               player.IsCrouching,
+		YVelocity:                 player.YVelocity,
+		JumpPower:                 player.JumpPower,
+		LastDirectionalKeyPressed: player.LastDirectionalKeyPressed,
+		InteractRange:             player.InteractRange,
+		AlreadyInteracted:         player.AlreadyInteracted,
+		StepHeight:                player.StepHeight,
+		Controls:                  append([]int32{}, player.Controls[:]...),
+		CurrentInputs:             append([]bool{}, player.CurrentInputs[:]...),
+		Camera:                    player.Camera,
+		PushStrength:              player.PushStrength,
+		MaxSlopeAngle:             player.MaxSlopeAngle,
+		HorizontalVelocity:        player.HorizontalVelocity,
+		Movement:                  getMovementControllerName(player.Movement),
+		Quake:                     player.Quake,
+		WasJumpHeld:               player.WasJumpHeld,
+		CoyoteTimer:               player.CoyoteTimer,
+		JumpBufferTimer:           player.JumpBufferTimer,
+		IsJumping:                 player.IsJumping,
+		JumpAssists:               player.JumpAssists,
+	}
+}
+
+// Restores the state of the player, player.Input is kept and player.Movement when the state has no built-in movement controller
+//
+// #1 argument state: *PlayerState - the state to restore
+func (player *Player) setState(state *PlayerState) {
+	player.Speed = state.Speed
+	player.MouseSensitivity = state.MouseSensitivity
+	player.Fovs = state.Fovs
+	player.BoundingBox = state.BoundingBox
+	player.Position = state.Position
+	player.OffsetNextFrame = state.OffsetNextFrame
+	player.Rotation = state.Rotation
+	player.Scale = state.Scale
+	player.ConstScale = state.ConstScale
+	player.IsCrouching = state.IsCrouching
+	player.YVelocity = state.YVelocity
+	player.HorizontalVelocity = state.HorizontalVelocity
+	// Other controllers can't be saved, the player's current one is kept
+	if movement := newMovementController(state.Movement); movement != nil {
+		player.Movement = movement
+	}
+	player.Quake = state.Quake
+	player.WasJumpHeld = state.WasJumpHeld
+	player.CoyoteTimer = state.CoyoteTimer
+	player.JumpBufferTimer = state.JumpBufferTimer
+	player.IsJumping = state.IsJumping
+	player.JumpAssists = state.JumpAssists
+	player.JumpPower = state.JumpPower
+	player.LastDirectionalKeyPressed = state.LastDirectionalKeyPressed
+	player.InteractRange = state.InteractRange
+	player.AlreadyInteracted = state.AlreadyInteracted
+	player.StepHeight = state.StepHeight
+	copy(player.Controls[:], state.Controls)
+	copy(player.CurrentInputs[:], state.CurrentInputs)
+	player.Camera = state.Camera
+	player.PushStrength = state.PushStrength
+	player.MaxSlopeAngle = state.MaxSlopeAngle
+}
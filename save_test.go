@@ -0,0 +1,59 @@
+package rlfp
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// A loaded save continues exactly like the saved world in both formats
+func TestSaveLoad(t *testing.T) {
+	for _, binary := range []bool{false, true} {
+		world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+		world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: -3., Y: 0., Z: -1.}, Max: rl.Vector3{X: -2., Y: .3, Z: 1.}})
+		world.AddTriggerBox(rl.BoundingBox{Min: rl.Vector3{X: -5., Y: 0., Z: -1.}, Max: rl.Vector3{X: -4., Y: 1., Z: 1.}})
+		world.AddInteractableBox(rl.BoundingBox{Min: rl.Vector3{X: -5., Y: 0., Z: -1.}, Max: rl.Vector3{X: -4., Y: 1., Z: 1.}})
+		inputs := getTestInputs(ControlForward, ControlJump)
+		stepTestWorld(world, 37, inputs)
+
+		buffer := &bytes.Buffer{}
+		loaded := &World{}
+		loaded.Init(0.)
+		var err error
+		if binary {
+			if err = world.SaveBinary(buffer); err == nil {
+				err = loaded.LoadBinary(buffer)
+			}
+		} else {
+			if err = world.Save(buffer); err == nil {
+				err = loaded.Load(buffer)
+			}
+		}
+		if err != nil {
+			t.Fatalf("binary %t: %v", binary, err)
+		}
+
+		stepTestWorld(world, 50, inputs)
+		stepTestWorld(loaded, 50, inputs)
+		if world.GetChecksum() != loaded.GetChecksum() {
+			t.Errorf("binary %t: loaded world diverged at %v, want %v", binary, loaded.Player.Position, world.Player.Position)
+		}
+	}
+}
+
+// Saves of other versions and files that aren't saves are rejected
+func TestLoadRejectsInvalidSaves(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+
+	if err := world.Load(strings.NewReader(`{"Version":1000,"World":{}}`)); err == nil {
+		t.Error("save of a newer version was loaded")
+	}
+	if err := world.Load(strings.NewReader(`{"Version":1}`)); err == nil {
+		t.Error("save without a world state was loaded")
+	}
+	if err := world.LoadBinary(strings.NewReader("RLFR")); err == nil {
+		t.Error("binary save with a wrong magic was loaded")
+	}
+}
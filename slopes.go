@@ -0,0 +1,91 @@
+package rlfp
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"github.com/chewxy/math32"
+)
+
+// Checks if the player can walk on a surface
+//
+// #1 argument normal: rl.Vector3 - normal of the surface
+//
+// #1 return: bool - true if the surface isn't steeper than player.MaxSlopeAngle
+func (player *Player) isWalkable(normal rl.Vector3) bool {
+	return normal.Y > 0. && normal.Y >= player.getMinGroundNormalY()-1e-5
+}
+
+// Gets the lowest Y of the normal of a surface the player can walk on
+//
+// #1 return: float32 - cosine of player.MaxSlopeAngle
+func (player *Player) getMinGroundNormalY() float32 {
+	return math32.Cos(rl.Clamp(player.MaxSlopeAngle, 0., 89.) * rl.Deg2rad)
+}
+
+// Gets how far under the player the ground is looked for, the gap left after sliding along a slope is higher on steeper slopes
+//
+// #1 return: float32 - the distance
+func (world *World) getGroundProbeDistance() float32 {
+	return world.FloatPrecision * 2. / world.Player.getMinGroundNormalY()
+}
+
+// Finds the surface right under the player
+//
+// #1 return: SweepHit - the surface, Hit is false when there is nothing right under the player
+func (world *World) getPlayerGround() SweepHit {
+	return world.sweepBox(world.Player.BoundingBox, rl.Vector3{X: 0., Y: -world.getGroundProbeDistance(), Z: 0.})
+}
+
+// Gets the Y offset which keeps a horizontal movement on a surface
+//
+// #1 argument offset: rl.Vector3 - the movement, only X and Z are used
+//
+// #2 argument normal: rl.Vector3 - normal of the surface
+//
+// #1 return: float32 - how much the player goes up or down
+func getSlopeOffsetY(offset rl.Vector3, normal rl.Vector3) float32 {
+	return -(normal.X*offset.X + normal.Z*offset.Z) / normal.Y
+}
+
+// Gets the rest of the movement after hitting a slope which is too steep to walk on
+// The slope stops the horizontal movement into it like a wall and the vertical movement slides along it
+//
+// #1 argument offset: rl.Vector3 - the rest of the movement
+//
+// #2 argument normal: rl.Vector3 - normal of the slope
+//
+// #1 return: rl.Vector3 - the movement along the slope
+func getSteepSlopeSlide(offset rl.Vector3, normal rl.Vector3) rl.Vector3 {
+	horizontal := rl.Vector3{X: offset.X, Y: 0., Z: offset.Z}
+	wall := rl.Vector3Normalize(rl.Vector3{X: normal.X, Y: 0., Z: normal.Z})
+	if into := rl.Vector3DotProduct(horizontal, wall); into < 0. {
+		horizontal = rl.Vector3Subtract(horizontal, rl.Vector3Scale(wall, into))
+	}
+
+	vertical := rl.Vector3{X: 0., Y: offset.Y, Z: 0.}
+	vertical = rl.Vector3Subtract(vertical, rl.Vector3Scale(normal, rl.Vector3DotProduct(vertical, normal)))
+
+	return rl.Vector3Add(horizontal, vertical)
+}
+
+// Moves the player down onto walkable ground, keeps the player on the ground when walking down a slope
+//
+// #1 argument distance: float32 - how far the player walked horizontally
+func (world *World) snapPlayerToGround(distance float32) {
+	if world.isPlayerOnGroundNextFrame() {
+		return
+	}
+
+	// The ground can't be lower than the steepest walkable slope goes, a step is the limit
+	min_normal_y := world.Player.getMinGroundNormalY()
+	drop := distance*math32.Sqrt(1.-min_normal_y*min_normal_y)/min_normal_y + world.getGroundProbeDistance()
+	drop = math32.Min(drop, world.Player.StepHeight)
+
+	offset := rl.Vector3{X: 0., Y: -drop, Z: 0.}
+	hit := world.sweepBox(world.Player.BoundingBox, offset)
+	if !hit.Hit || !world.Player.isWalkable(hit.Normal) {
+		return
+	}
+
+	world.setPlayerBoundingBox(moveBox(world.Player.BoundingBox, rl.Vector3Add(rl.Vector3Scale(offset, hit.Time), rl.Vector3Scale(hit.Normal, world.FloatPrecision))))
+}
@@ -0,0 +1,79 @@
+package rlfp
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"github.com/chewxy/math32"
+)
+
+// Creates a world with a ramp rising to +X from X 2 to 7 and a platform at the top of it
+//
+// #1 argument position: rl.Vector3 - player's position
+//
+// #2 argument height: float32 - height of the ramp, 2.8867 for a 30 degree ramp
+//
+// #1 return: *World - the new world
+func newRampTestWorld(position rl.Vector3, height float32) *World {
+	world := newTestWorld(position)
+	world.AddCollider(RampCollider{Box: rl.BoundingBox{Min: rl.Vector3{X: 2., Y: 0., Z: -3.}, Max: rl.Vector3{X: 7., Y: height, Z: 3.}}, Direction: RampRisingPositiveX})
+	world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: 7., Y: 0., Z: -3.}, Max: rl.Vector3{X: 12., Y: height, Z: 3.}})
+
+	return world
+}
+
+// Walks the player and counts the frames the player is in the air
+//
+// #1 argument world: *World - the world
+//
+// #2 argument frames: int - number of frames
+//
+// #3 argument control: int - the held control
+//
+// #1 return: int - number of frames in the air
+func walkOnTestRamp(world *World, frames int, control int) int {
+	air := 0
+	for i := 0; i < frames; i++ {
+		stepTestWorld(world, 1, getTestInputs(control))
+		if !world.isPlayerOnGroundNextFrame() {
+			air++
+		}
+	}
+
+	return air
+}
+
+// The player walks up a walkable ramp and down it without leaving the ground
+func TestWalkableRamp(t *testing.T) {
+	world := newRampTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.}, 2.8867)
+	stepTestWorld(world, 30, [ControlCount]bool{})
+
+	if air := walkOnTestRamp(world, 180, ControlBackward); air != 0 {
+		t.Errorf("player was in the air for %d frames walking up the ramp", air)
+	}
+	if world.Player.BoundingBox.Min.X < 7. || math32.Abs(world.Player.BoundingBox.Min.Y-2.8867) > .01 {
+		t.Fatalf("player walked to %v, want on the platform", world.Player.BoundingBox.Min)
+	}
+
+	if air := walkOnTestRamp(world, 150, ControlForward); air != 0 {
+		t.Errorf("player was in the air for %d frames walking down the ramp", air)
+	}
+	if world.Player.BoundingBox.Max.X > 7. || world.Player.BoundingBox.Min.Y > 2.8 {
+		t.Errorf("player walked to %v, want down the ramp", world.Player.BoundingBox.Min)
+	}
+}
+
+// The player slides down a ramp steeper than the max slope angle and can't walk up it
+func TestSteepRamp(t *testing.T) {
+	world := newRampTestWorld(rl.Vector3{X: 4., Y: 5., Z: 0.}, 8.66)
+	stepTestWorld(world, 120, [ControlCount]bool{})
+	if world.Player.BoundingBox.Max.X > 2.1 || world.Player.BoundingBox.Min.Y > .01 {
+		t.Fatalf("player stayed at %v, want slid down the ramp", world.Player.BoundingBox.Min)
+	}
+
+	stepTestWorld(world, 120, getTestInputs(ControlBackward))
+	if world.Player.BoundingBox.Min.Y > .5 {
+		t.Errorf("player walked up the steep ramp to Y %g", world.Player.BoundingBox.Min.Y)
+	}
+}
@@ -0,0 +1,345 @@
+package rlfp
+
+import (
+	"sort"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"github.com/chewxy/math32"
+)
+
+// Boxes covering more cells than this in one level of the grid are put in the next level with bigger cells
+const spatialGridMaxCells = 64
+
+// Number of levels of the grid, boxes too big for the last level are kept in one list, which is returned by every query
+const spatialGridLevels = 3
+
+// How many times bigger the cells of a level are than the cells of the previous level
+const spatialGridLevelScale = 8
+
+// Hierarchical grid on the X and Z axis, used for finding boxes near an area without checking every box in the world
+// Small boxes are in the smallest cells, large floors and walls in the bigger cells of the next levels
+type spatialGrid struct {
+	cell_size float32
+	// Cells of every level, the cells of level i are cell_size * spatialGridLevelScale^i big
+	levels [spatialGridLevels]map[[2]int32][]int
+	// Boxes that are too big to be put in cells
+	large []int
+	// Boxes as they were inserted, so they are removed from the right cells even when they were changed since
+	boxes []rl.BoundingBox
+	// Number of inserted boxes
+	count int
+	// Used for returning every box only once from a query
+	stamps []uint32
+	stamp  uint32
+}
+
+// Removes every box from the grid
+//
+// #1 argument cell_size: float32 - size of one cell of the smallest level of the grid
+func (grid *spatialGrid) init(cell_size float32) {
+	grid.cell_size = cell_size
+	for i := range grid.levels {
+		grid.levels[i] = map[[2]int32][]int{}
+	}
+	grid.large = []int{}
+	grid.boxes = grid.boxes[:0]
+	grid.count = 0
+}
+
+// Checks if the grid was initialized
+//
+// #1 return: bool - true if grid.init was called
+func (grid *spatialGrid) isInitialized() bool {
+	return grid.levels[0] != nil
+}
+
+// Gets the range of cells covered by a box in a level
+//
+// #1 argument box: rl.BoundingBox - the box
+//
+// #2 argument level: int - the level of the grid
+//
+// #1 return: [2]int32 - the minimum cell
+//
+// #2 return: [2]int32 - the maximum cell
+//
+// #3 return: bool - true if the box covers too many cells of the level
+func (grid *spatialGrid) getCellRange(box rl.BoundingBox, level int) ([2]int32, [2]int32, bool) {
+	cell_size := grid.cell_size
+	for i := 0; i < level; i++ {
+		cell_size *= spatialGridLevelScale
+	}
+
+	min_cell := [2]int32{int32(math32.Floor(box.Min.X / cell_size)), int32(math32.Floor(box.Min.Z / cell_size))}
+	max_cell := [2]int32{int32(math32.Floor(box.Max.X / cell_size)), int32(math32.Floor(box.Max.Z / cell_size))}
+
+	return min_cell, max_cell, getCellCount(min_cell, max_cell) > spatialGridMaxCells
+}
+
+// Gets the number of cells in a range of cells
+//
+// #1 argument min_cell: [2]int32 - the minimum cell
+//
+// #2 argument max_cell: [2]int32 - the maximum cell
+//
+// #1 return: int64 - the number of cells
+func getCellCount(min_cell, max_cell [2]int32) int64 {
+	return (int64(max_cell[0]) - int64(min_cell[0]) + 1) * (int64(max_cell[1]) - int64(min_cell[1]) + 1)
+}
+
+// Gets the level of the grid where a box is stored
+//
+// #1 argument box: rl.BoundingBox - the box
+//
+// #1 return: int - the level, -1 if the box is too big for every level and belongs to grid.large
+//
+// #2 return: [2]int32 - the minimum cell in the level
+//
+// #3 return: [2]int32 - the maximum cell in the level
+func (grid *spatialGrid) getBoxLevel(box rl.BoundingBox) (int, [2]int32, [2]int32) {
+	for level := range grid.levels {
+		if min_cell, max_cell, too_big := grid.getCellRange(box, level); !too_big {
+			return level, min_cell, max_cell
+		}
+	}
+
+	return -1, [2]int32{}, [2]int32{}
+}
+
+// Inserts a box into the grid
+//
+// #1 argument key: int - the index of the box
+//
+// #2 argument box: rl.BoundingBox - the box
+func (grid *spatialGrid) insert(key int, box rl.BoundingBox) {
+	grid.count++
+	for key >= len(grid.boxes) {
+		grid.boxes = append(grid.boxes, rl.BoundingBox{})
+	}
+	grid.boxes[key] = box
+
+	level, min_cell, max_cell := grid.getBoxLevel(box)
+	if level == -1 {
+		grid.large = append(grid.large, key)
+		return
+	}
+
+	cells := grid.levels[level]
+	for x := min_cell[0]; x <= max_cell[0]; x++ {
+		for z := min_cell[1]; z <= max_cell[1]; z++ {
+			cells[[2]int32{x, z}] = append(cells[[2]int32{x, z}], key)
+		}
+	}
+}
+
+// Removes a box from the grid
+//
+// #1 argument key: int - the index of the box
+func (grid *spatialGrid) remove(key int) {
+	grid.count--
+
+	level, min_cell, max_cell := grid.getBoxLevel(grid.boxes[key])
+	if level == -1 {
+		grid.large = removeKey(grid.large, key)
+		return
+	}
+
+	cells := grid.levels[level]
+	for x := min_cell[0]; x <= max_cell[0]; x++ {
+		for z := min_cell[1]; z <= max_cell[1]; z++ {
+			if keys := removeKey(cells[[2]int32{x, z}], key); len(keys) > 0 {
+				cells[[2]int32{x, z}] = keys
+			} else {
+				delete(cells, [2]int32{x, z})
+			}
+		}
+	}
+}
+
+// Finds every box in the cells overlapped by an area, the boxes still have to be checked for collisions
+//
+// #1 argument box: rl.BoundingBox - the area
+//
+// #2 argument extra: []int - keys which are always returned
+//
+// #3 argument result: []int - slice where the keys are appended, used for reusing memory
+//
+// #1 return: []int - the keys of the boxes in ascending order
+func (grid *spatialGrid) query(box rl.BoundingBox, extra []int, result []int) []int {
+	grid.stamp++
+	if grid.stamp == 0 {
+		clear(grid.stamps)
+		grid.stamp = 1
+	}
+
+	result = grid.appendUnique(result, extra)
+	result = grid.appendUnique(result, grid.large)
+
+	for level, cells := range grid.levels {
+		min_cell, max_cell, too_big := grid.getCellRange(box, level)
+
+		// Walking the cells of a long sweep would take longer than going through every occupied cell of the level
+		if too_big && getCellCount(min_cell, max_cell) > int64(len(cells)) {
+			for _, keys := range cells {
+				result = grid.appendUnique(result, keys)
+			}
+			continue
+		}
+
+		for x := min_cell[0]; x <= max_cell[0]; x++ {
+			for z := min_cell[1]; z <= max_cell[1]; z++ {
+				result = grid.appendUnique(result, cells[[2]int32{x, z}])
+			}
+		}
+	}
+
+	// Keep the order of the world's slices, so the first colliding box is the same as without the grid
+	sort.Ints(result)
+
+	return result
+}
+
+// Appends the keys which weren't returned in the current query yet
+//
+// #1 argument result: []int - slice where the keys are appended
+//
+// #2 argument keys: []int - keys to append
+//
+// #1 return: []int - the result with the new keys
+func (grid *spatialGrid) appendUnique(result []int, keys []int) []int {
+	for _, key := range keys {
+		for key >= len(grid.stamps) {
+			grid.stamps = append(grid.stamps, 0)
+		}
+
+		if grid.stamps[key] != grid.stamp {
+			grid.stamps[key] = grid.stamp
+			result = append(result, key)
+		}
+	}
+
+	return result
+}
+
+// Removes the first occurrence of a key from a slice without keeping the order
+//
+// #1 argument keys: []int - the slice
+//
+// #2 argument key: int - the key to remove
+//
+// #1 return: []int - the slice without the key
+func removeKey(keys []int, key int) []int {
+	for i := range keys {
+		if keys[i] == key {
+			keys[i] = keys[len(keys)-1]
+			return keys[:len(keys)-1]
+		}
+	}
+
+	return keys
+}
+
+// Rebuilds the spatial grids, the ID lookups and the lists of kinematic, dynamic and active boxes
+// Should be called after changing world.BoundingBoxes, world.TriggerBoxes, world.InteractableBoxes, world.Colliders or world.GridCellSize directly
+// The grids aren't updated by changing the boxes in place, a box moved without it is only found near its old position
+func (world *World) RebuildSpatialGrids() {
+	world.rebuildBoxIndexes()
+
+	world.bounding_box_grid.init(world.GridCellSize)
+	world.kinematic_boxes = []int{}
+	world.dynamic_boxes = []int{}
+	for i := range world.BoundingBoxes {
+		world.bounding_box_grid.insert(i, world.BoundingBoxes[i].BoundingBox)
+		if world.BoundingBoxes[i].isKinematic() {
+			world.kinematic_boxes = append(world.kinematic_boxes, i)
+		}
+		if world.BoundingBoxes[i].Dynamic {
+			world.dynamic_boxes = append(world.dynamic_boxes, i)
+		}
+	}
+
+	world.trigger_box_grid.init(world.GridCellSize)
+	world.active_trigger_boxes = []int{}
+	for i := range world.TriggerBoxes {
+		world.trigger_box_grid.insert(i, world.TriggerBoxes[i].BoundingBox)
+		if world.TriggerBoxes[i].Triggered || world.TriggerBoxes[i].Triggering {
+			world.active_trigger_boxes = append(world.active_trigger_boxes, i)
+		}
+	}
+
+	world.interactable_box_grid.init(world.GridCellSize)
+	world.active_interactable_boxes = []int{}
+	for i := range world.InteractableBoxes {
+		world.interactable_box_grid.insert(i, world.InteractableBoxes[i].BoundingBox)
+		if world.InteractableBoxes[i].isActive() {
+			world.active_interactable_boxes = append(world.active_interactable_boxes, i)
+		}
+	}
+
+	world.collider_grid.init(world.GridCellSize)
+	for i := range world.Colliders {
+		world.Colliders[i].BoundingBox = world.Colliders[i].Collider.GetBoundingBox()
+		world.collider_grid.insert(i, world.Colliders[i].BoundingBox)
+	}
+}
+
+// Finds the bounding boxes that can collide with an area
+//
+// #1 argument box: rl.BoundingBox - the area
+//
+// #1 return: []int - indexes of world.BoundingBoxes in ascending order, valid until the next query
+func (world *World) queryBoundingBoxes(box rl.BoundingBox) []int {
+	if !world.bounding_box_grid.isInitialized() || world.bounding_box_grid.count != len(world.BoundingBoxes) {
+		world.RebuildSpatialGrids()
+	}
+
+	world.bounding_box_query = world.bounding_box_grid.query(box, nil, world.bounding_box_query[:0])
+
+	return world.bounding_box_query
+}
+
+// Finds the trigger boxes that can collide with an area and the ones that were triggered last frame
+//
+// #1 argument box: rl.BoundingBox - the area
+//
+// #1 return: []int - indexes of world.TriggerBoxes in ascending order, including the active ones, valid until the next query
+func (world *World) queryTriggerBoxes(box rl.BoundingBox) []int {
+	if !world.trigger_box_grid.isInitialized() || world.trigger_box_grid.count != len(world.TriggerBoxes) {
+		world.RebuildSpatialGrids()
+	}
+
+	world.trigger_box_query = world.trigger_box_grid.query(box, world.active_trigger_boxes, world.trigger_box_query[:0])
+
+	return world.trigger_box_query
+}
+
+// Finds the interactable boxes that can collide with an area and the ones that were hit or interacted with last frame
+//
+// #1 argument box: rl.BoundingBox - the area
+//
+// #1 return: []int - indexes of world.InteractableBoxes in ascending order, including the active ones, valid until the next query
+func (world *World) queryInteractableBoxes(box rl.BoundingBox) []int {
+	if !world.interactable_box_grid.isInitialized() || world.interactable_box_grid.count != len(world.InteractableBoxes) {
+		world.RebuildSpatialGrids()
+	}
+
+	world.interactable_box_query = world.interactable_box_grid.query(box, world.active_interactable_boxes, world.interactable_box_query[:0])
+
+	return world.interactable_box_query
+}
+
+// Finds the colliders that can collide with an area
+//
+// #1 argument box: rl.BoundingBox - the area
+//
+// #1 return: []int - indexes of world.Colliders in ascending order, valid until the next query
+func (world *World) queryColliders(box rl.BoundingBox) []int {
+	if !world.collider_grid.isInitialized() || world.collider_grid.count != len(world.Colliders) {
+		world.RebuildSpatialGrids()
+	}
+
+	world.collider_query = world.collider_grid.query(box, nil, world.collider_query[:0])
+
+	return world.collider_query
+}
@@ -0,0 +1,164 @@
+package rlfp
+
+import (
+	"math/rand"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Creates a world with randomly placed small boxes around the player, every tenth one with a trigger and an interactable box above it
+//
+// #1 argument count: int - number of bounding boxes
+//
+// #1 return: *World - the new world
+func newSpatialTestWorld(count int) *World {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	random := rand.New(rand.NewSource(1))
+
+	for i := 0; i < count; i++ {
+		x, y, z := random.Float32()*400.-200., random.Float32()*3., random.Float32()*400.-200.
+		if x > -3. && x < 3. && z > -3. && z < 3. {
+			continue
+		}
+
+		world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: x, Y: y, Z: z}, Max: rl.Vector3{X: x + 1., Y: y + .5, Z: z + 1.}})
+		if i%10 == 0 {
+			world.AddTriggerBox(rl.BoundingBox{Min: rl.Vector3{X: x, Y: y + 2., Z: z}, Max: rl.Vector3{X: x + 1., Y: y + 2.5, Z: z + 1.}})
+			world.AddInteractableBox(rl.BoundingBox{Min: rl.Vector3{X: x, Y: y + 4., Z: z}, Max: rl.Vector3{X: x + 1., Y: y + 4.5, Z: z + 1.}})
+		}
+	}
+	world.CalculationDistance = 1e6
+
+	return world
+}
+
+// Finds the bounding boxes colliding with an area by checking every box, like the world did before the grid
+//
+// #1 argument world: *World - the world
+//
+// #2 argument area: rl.BoundingBox - the area
+//
+// #3 argument result: []int - slice where the indexes are appended
+//
+// #1 return: []int - indexes of the colliding boxes
+func queryBoundingBoxesLinear(world *World, area rl.BoundingBox, result []int) []int {
+	for i := range world.BoundingBoxes {
+		if rl.CheckCollisionBoxes(area, world.BoundingBoxes[i].BoundingBox) {
+			result = append(result, i)
+		}
+	}
+
+	return result
+}
+
+// The grid finds every box colliding with an area, including boxes of every size
+func TestSpatialGridQuery(t *testing.T) {
+	world := newSpatialTestWorld(2000)
+	random := rand.New(rand.NewSource(2))
+	for _, size := range []float32{10., 100., 1000., 100000.} {
+		x, z := random.Float32()*400.-200., random.Float32()*400.-200.
+		world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: x, Y: 0., Z: z}, Max: rl.Vector3{X: x + size, Y: 1., Z: z + size}})
+	}
+
+	for i := 0; i < 1000; i++ {
+		x, z, size := random.Float32()*500.-250., random.Float32()*500.-250., random.Float32()*20.
+		area := rl.BoundingBox{Min: rl.Vector3{X: x, Y: 0., Z: z}, Max: rl.Vector3{X: x + size, Y: 5., Z: z + size}}
+
+		found := map[int]bool{}
+		for _, key := range world.queryBoundingBoxes(area) {
+			found[key] = true
+		}
+		for _, key := range queryBoundingBoxesLinear(world, area, nil) {
+			if !found[key] {
+				t.Fatalf("box %d %v colliding with %v wasn't found", key, world.BoundingBoxes[key].BoundingBox, area)
+			}
+		}
+	}
+}
+
+// A box changed through the world methods is found only at its new position
+func TestSpatialGridMovedBox(t *testing.T) {
+	world := newTestWorld(rl.Vector3{X: 0., Y: 1., Z: 0.})
+	old_area := rl.BoundingBox{Min: rl.Vector3{X: 10., Y: 0., Z: 10.}, Max: rl.Vector3{X: 11., Y: 1., Z: 11.}}
+	new_area := rl.BoundingBox{Min: rl.Vector3{X: -50., Y: 0., Z: -50.}, Max: rl.Vector3{X: -49., Y: 1., Z: -49.}}
+	id := world.AddBoundingBox(old_area)
+	world.AddBoundingBox(rl.BoundingBox{Min: rl.Vector3{X: 30., Y: 0., Z: 30.}, Max: rl.Vector3{X: 31., Y: 1., Z: 31.}})
+
+	world.SetBoundingBoxBounds(id, new_area)
+	if keys := world.queryBoundingBoxes(old_area); len(keys) != 0 {
+		t.Errorf("moved box is still found at its old position %v", keys)
+	}
+	if keys := world.queryBoundingBoxes(new_area); len(keys) != 1 || world.BoundingBoxes[keys[0]].ID != id {
+		t.Errorf("found %v at the new position, want the moved box", keys)
+	}
+
+	// The grid removes the box from the cells it was inserted to, even when the box was changed since
+	world.BoundingBoxes[0].BoundingBox = old_area
+	world.RemoveBoundingBox(id)
+	if keys := world.queryBoundingBoxes(new_area); len(keys) != 0 {
+		t.Errorf("removed box is still found %v", keys)
+	}
+}
+
+// A query of a long sweep goes through the occupied cells instead of every cell of the sweep
+func TestSpatialGridLongSweep(t *testing.T) {
+	world := newSpatialTestWorld(10000)
+	area := rl.BoundingBox{Min: rl.Vector3{X: -1e6, Y: 0., Z: -1e6}, Max: rl.Vector3{X: 1e6, Y: 5., Z: 1e6}}
+	if keys, want := world.queryBoundingBoxes(area), queryBoundingBoxesLinear(world, area, nil); len(keys) != len(want) {
+		t.Errorf("found %d boxes in the sweep, want %d", len(keys), len(want))
+	}
+
+	// Above every box, so the player isn't stopped by them
+	world.Player.New(rl.Vector3{X: 0., Y: 10., Z: 0.}, rl.Vector2{X: 0., Y: 0.}, false)
+	world.Gravity = 0.
+	world.Player.HorizontalVelocity = rl.Vector2{X: 1e6, Y: 1e6}
+	world.Step(1., [ControlCount]bool{}, rl.Vector2{X: 0., Y: 0.})
+	if world.Player.Position.X < 1e5 || world.Player.Position.Z < 1e5 {
+		t.Errorf("player moved to %v, want far away diagonally", world.Player.Position)
+	}
+}
+
+// Finds the bounding boxes near the player with the grid
+func BenchmarkQueryBoundingBoxesGrid(b *testing.B) {
+	world := newSpatialTestWorld(10000)
+	area := world.Player.BoundingBox
+	world.queryBoundingBoxes(area)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		world.queryBoundingBoxes(area)
+	}
+}
+
+// Finds the bounding boxes near the player by checking every box
+func BenchmarkQueryBoundingBoxesLinear(b *testing.B) {
+	world := newSpatialTestWorld(10000)
+	area := world.Player.BoundingBox
+	result := []int{}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result = queryBoundingBoxesLinear(world, area, result[:0])
+	}
+}
+
+// Steps a world with boxes while walking and turning
+//
+// #1 argument b: *testing.B - the benchmark
+//
+// #2 argument count: int - number of bounding boxes
+func benchmarkStep(b *testing.B, count int) {
+	world := newSpatialTestWorld(count)
+	inputs := getTestInputs(ControlForward)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		world.Step(1./60., inputs, rl.Vector2{X: 3., Y: 0.})
+	}
+}
+
+// Steps worlds with 1000, 10000 and 50000 bounding boxes
+func BenchmarkStep1k(b *testing.B)  { benchmarkStep(b, 1000) }
+func BenchmarkStep10k(b *testing.B) { benchmarkStep(b, 10000) }
+func BenchmarkStep50k(b *testing.B) { benchmarkStep(b, 50000) }
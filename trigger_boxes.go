@@ -6,37 +6,208 @@ import (
 
 // Used when a player is inside a trigger box
 type TriggerBox struct {
+	// Stable identifier of the box
+	ID BoxID
 	// The box that triggers the event
 	BoundingBox rl.BoundingBox
+	// Rotated shape of the box, used instead of BoundingBox when IsOriented is true, BoundingBox is then the axis-aligned box around it
+	Oriented OrientedBox
+	// If the box has a rotated shape
+	IsOriented bool
 	// If the player is inside the box (one frame)
 	Triggered bool
 	// If the player is inside the box (staying inside)
 	Triggering bool
+	// If the box is updated even when it's farther than world.CalculationDistance
+	AlwaysActive bool
+	// If the box can't be triggered
+	Disabled bool
+}
+
+// Functions called when an entity enters, stays inside or exits a trigger box, any of them can be nil
+// They are called after every trigger box is updated, so they can change the world's boxes
+type TriggerCallbacks struct {
+	OnEnter func(world *World, event Event)
+	OnStay  func(world *World, event Event)
+	OnExit  func(world *World, event Event)
 }
 
 // Creates a new trigger box and puts it in world.TriggerBoxes array
 //
 // #1 argument box: rl.BoundingBox - the box that triggers the event
-func (world *World) AddTriggerBox(box rl.BoundingBox) {
-	world.TriggerBoxes = append(world.TriggerBoxes, TriggerBox{box, false, false})
+//
+// #1 return: BoxID - stable ID of the new box
+func (world *World) AddTriggerBox(box rl.BoundingBox) BoxID {
+	id := world.newBoxID()
+
+	world.TriggerBoxes = append(world.TriggerBoxes, TriggerBox{
+		ID:          id,
+		BoundingBox: box,
+	})
+	if world.trigger_box_grid.isInitialized() {
+		world.trigger_box_grid.insert(len(world.TriggerBoxes)-1, box)
+	}
+	if world.trigger_box_indexes != nil {
+		world.trigger_box_indexes[id] = len(world.TriggerBoxes) - 1
+	}
+
+	return id
+}
+
+// Removes a trigger box from the world, the last box takes its place in world.TriggerBoxes
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #1 return: bool - false if there is no trigger box with the ID
+func (world *World) RemoveTriggerBox(id BoxID) bool {
+	i := world.getBoxIndex(&world.trigger_box_indexes, len(world.TriggerBoxes), id)
+	if i == -1 {
+		return false
+	}
+	last := len(world.TriggerBoxes) - 1
+	was_triggering := world.TriggerBoxes[i].Triggering
+
+	world.trigger_box_grid.remove(i)
+	if i != last {
+		world.trigger_box_grid.remove(last)
+		world.trigger_box_grid.insert(i, world.TriggerBoxes[last].BoundingBox)
+		world.TriggerBoxes[i] = world.TriggerBoxes[last]
+		world.trigger_box_indexes[world.TriggerBoxes[i].ID] = i
+	}
+	world.TriggerBoxes = world.TriggerBoxes[:last]
+	delete(world.trigger_box_indexes, id)
+	world.active_trigger_boxes = fixKeysAfterRemove(world.active_trigger_boxes, i, last)
+
+	if was_triggering {
+		world.addTriggerEvent(EventTriggerExit, id)
+	}
+	world.dispatchTriggerEvents()
+	delete(world.trigger_callbacks, id)
+
+	return true
+}
+
+// Moves or resizes a trigger box, an oriented box becomes axis-aligned
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #2 argument box: rl.BoundingBox - the new box
+//
+// #1 return: bool - false if there is no trigger box with the ID
+func (world *World) SetTriggerBoxBounds(id BoxID, box rl.BoundingBox) bool {
+	i := world.getBoxIndex(&world.trigger_box_indexes, len(world.TriggerBoxes), id)
+	if i == -1 {
+		return false
+	}
+
+	world.trigger_box_grid.remove(i)
+	world.TriggerBoxes[i].BoundingBox = box
+	world.TriggerBoxes[i].IsOriented = false
+	world.trigger_box_grid.insert(i, box)
+
+	return true
+}
+
+// Gets a trigger box by its ID
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #1 return: TriggerBox - copy of the box
+//
+// #2 return: bool - false if there is no trigger box with the ID
+func (world *World) GetTriggerBox(id BoxID) (TriggerBox, bool) {
+	i := world.getBoxIndex(&world.trigger_box_indexes, len(world.TriggerBoxes), id)
+	if i == -1 {
+		return TriggerBox{}, false
+	}
+
+	return world.TriggerBoxes[i], true
+}
+
+// Enables or disables a trigger box, disabling resets its states
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #2 argument enabled: bool - if the box can be triggered
+//
+// #1 return: bool - false if there is no trigger box with the ID
+func (world *World) SetTriggerBoxEnabled(id BoxID, enabled bool) bool {
+	i := world.getBoxIndex(&world.trigger_box_indexes, len(world.TriggerBoxes), id)
+	if i == -1 {
+		return false
+	}
+
+	world.TriggerBoxes[i].Disabled = !enabled
+	if !enabled {
+		if world.TriggerBoxes[i].Triggering {
+			world.addTriggerEvent(EventTriggerExit, id)
+		}
+		world.TriggerBoxes[i].Triggered = false
+		world.TriggerBoxes[i].Triggering = false
+		world.dispatchTriggerEvents()
+	}
+
+	return true
+}
+
+// Sets the functions called when the player enters, stays inside or exits a trigger box
+//
+// #1 argument id: BoxID - ID of the box
+//
+// #2 argument callbacks: TriggerCallbacks - the functions, replace the previous ones
+//
+// #1 return: bool - false if there is no trigger box with the ID
+func (world *World) SetTriggerBoxCallbacks(id BoxID, callbacks TriggerCallbacks) bool {
+	if world.getBoxIndex(&world.trigger_box_indexes, len(world.TriggerBoxes), id) == -1 {
+		return false
+	}
+
+	if world.trigger_callbacks == nil {
+		world.trigger_callbacks = map[BoxID]TriggerCallbacks{}
+	}
+	world.trigger_callbacks[id] = callbacks
+
+	return true
 }
 
 // Updates all trigger boxes
 func (world *World) UpdateTriggerBoxes() {
-	for i := range world.TriggerBoxes {
-		if getDistance(world.Player.Position.X, world.Player.Position.Z,
-			world.TriggerBoxes[i].BoundingBox.Min.X, world.TriggerBoxes[i].BoundingBox.Min.Z) <= world.CalculationDistance {
+	candidates := world.queryTriggerBoxes(world.Player.BoundingBox)
+
+	world.active_trigger_boxes = world.active_trigger_boxes[:0]
+	for _, i := range candidates {
+		if !world.TriggerBoxes[i].Disabled &&
+			world.isInCalculationDistance(world.TriggerBoxes[i].BoundingBox, world.TriggerBoxes[i].AlwaysActive) {
 
 			world.UpdateTriggerBox(i)
 		}
+
+		// Remember the triggered boxes, so they are updated when the player leaves them
+		if world.TriggerBoxes[i].Triggered || world.TriggerBoxes[i].Triggering {
+			world.active_trigger_boxes = append(world.active_trigger_boxes, i)
+		}
 	}
+
+	world.dispatchTriggerEvents()
 }
 
 // Updates a trigger box
 //
 // #1 argument i: int - index of the trigger box
 func (world *World) UpdateTriggerBox(i int) {
-	is_colliding := rl.CheckCollisionBoxes(world.Player.BoundingBox, world.TriggerBoxes[i].BoundingBox)
+	// A player without interactions leaves every trigger box
+	is_colliding := world.Player.getMovement().HasInteractions() && rl.CheckCollisionBoxes(world.Player.BoundingBox, world.TriggerBoxes[i].BoundingBox)
+	if is_colliding && world.TriggerBoxes[i].IsOriented {
+		is_colliding = CheckCollisionBoxOrientedBox(world.Player.BoundingBox, world.TriggerBoxes[i].Oriented)
+	}
+
+	if is_colliding && !world.TriggerBoxes[i].Triggering {
+		world.addTriggerEvent(EventTriggerEnter, world.TriggerBoxes[i].ID)
+	} else if is_colliding {
+		world.addTriggerEvent(EventTriggerStay, world.TriggerBoxes[i].ID)
+	} else if world.TriggerBoxes[i].Triggering {
+		world.addTriggerEvent(EventTriggerExit, world.TriggerBoxes[i].ID)
+	}
 
 	if !world.TriggerBoxes[i].Triggering {
 		world.TriggerBoxes[i].Triggered = is_colliding
@@ -46,3 +217,49 @@ func (world *World) UpdateTriggerBox(i int) {
 
 	world.TriggerBoxes[i].Triggering = is_colliding
 }
+
+// Puts a trigger event caused by the player in the queue, its callback is called by world.dispatchTriggerEvents
+//
+// #1 argument event_type: int - type of the event (EventTriggerEnter, EventTriggerStay, EventTriggerExit)
+//
+// #2 argument id: BoxID - ID of the trigger box
+func (world *World) addTriggerEvent(event_type int, id BoxID) {
+	event := Event{
+		Type:      event_type,
+		BoxID:     id,
+		Entity:    Entity{Kind: EntityPlayer, ID: 0},
+		FrameTime: world.FrameTime,
+	}
+
+	world.pushEvent(event)
+	world.trigger_events = append(world.trigger_events, event)
+}
+
+// Calls the callbacks of the trigger events added since the last call
+func (world *World) dispatchTriggerEvents() {
+	// Callbacks can add new events, so the pending ones are taken first
+	events := world.trigger_events
+	world.trigger_events = nil
+
+	for _, event := range events {
+		callbacks, ok := world.trigger_callbacks[event.BoxID]
+		if !ok {
+			continue
+		}
+
+		switch event.Type {
+		case EventTriggerEnter:
+			if callbacks.OnEnter != nil {
+				callbacks.OnEnter(world, event)
+			}
+		case EventTriggerStay:
+			if callbacks.OnStay != nil {
+				callbacks.OnStay(world, event)
+			}
+		case EventTriggerExit:
+			if callbacks.OnExit != nil {
+				callbacks.OnExit(world, event)
+			}
+		}
+	}
+}
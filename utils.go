@@ -1,16 +1,37 @@
 package rlfp
 
-// Gets the distance between 2 points without rooting the result to make it faster
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Gets the distance between a point and the closest point of a box without rooting the result to make it faster
 //
-// #1 argument a: float32 - the x position of the first point
+// #1 argument point: rl.Vector3 - the point
 //
-// #2 argument b: float32 - the y position of the first point
+// #2 argument box: rl.BoundingBox - the box
 //
-// #3 argument c: float32 - the x position of the second point
+// #1 return: float32 - the distance squared (0 when the point is inside the box)
+func getBoxDistanceSquared(point rl.Vector3, box rl.BoundingBox) float32 {
+	closest := rl.Vector3Clamp(point, box.Min, box.Max)
+
+	return (point.X-closest.X)*(point.X-closest.X) + (point.Y-closest.Y)*(point.Y-closest.Y) + (point.Z-closest.Z)*(point.Z-closest.Z)
+}
+
+// Moves a vector towards a target by a limited distance
 //
-// #4 argument d: float32 - the y position of the second point
+// #1 argument vector: rl.Vector3 - the vector
 //
-// #1 return: float32 - the distance between the 2 points squared
-func getDistance(a, b, c, d float32) float32 {
-	return (a-c)*(a-c) + (b-d)*(b-d)
+// #2 argument target: rl.Vector3 - the target
+//
+// #3 argument max_distance: float32 - how far the vector can move
+//
+// #1 return: rl.Vector3 - the moved vector, the target if it's closer than max_distance
+func moveVector3Towards(vector rl.Vector3, target rl.Vector3, max_distance float32) rl.Vector3 {
+	difference := rl.Vector3Subtract(target, vector)
+	distance := rl.Vector3Length(difference)
+	if distance <= max_distance || distance == 0. {
+		return target
+	}
+
+	return rl.Vector3Add(vector, rl.Vector3Scale(difference, max_distance/distance))
 }